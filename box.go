@@ -0,0 +1,303 @@
+package ewkb
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/kcasctiv/go-ewkb/geo"
+)
+
+// Box2D presents PostGIS box2d, bounding box
+// of geometry, returned by ST_Extent
+type Box2D struct {
+	MinX, MinY float64
+	MaxX, MaxY float64
+}
+
+// NewBox2D returns bounding box of all points of polygon.
+// Bounding box of empty polygon is zero
+func NewBox2D(p geo.Polygon) Box2D {
+	var b Box3D
+	extendBox(&b, p, false)
+	return b.Box2D()
+}
+
+// Polygon returns envelope of box, created with geometry base,
+// as PostGIS ST_Envelope does. Z and M of points are zero
+func (b Box2D) Polygon(base Base) Polygon {
+	dims := coordDims{known: true, z: base.HasZ(), m: base.HasM()}
+	ring := geo.NewMultiPoint([]geo.Point{
+		dims.point([]float64{b.MinX, b.MinY, 0, 0}),
+		dims.point([]float64{b.MinX, b.MaxY, 0, 0}),
+		dims.point([]float64{b.MaxX, b.MaxY, 0, 0}),
+		dims.point([]float64{b.MaxX, b.MinY, 0, 0}),
+		dims.point([]float64{b.MinX, b.MinY, 0, 0}),
+	})
+
+	return NewPolygon(base, geo.NewPolygon([]geo.MultiPoint{ring}))
+}
+
+// String returns box representation in PostGIS format
+func (b Box2D) String() string {
+	return "BOX(" + printCoords(b.MinX, b.MinY) + "," + printCoords(b.MaxX, b.MaxY) + ")"
+}
+
+// Scan implements sql.Scanner interface
+func (b *Box2D) Scan(src interface{}) error {
+	vals, err := scanBox(src, "BOX", 2)
+	if err != nil {
+		return err
+	}
+
+	*b = Box2D{MinX: vals[0], MinY: vals[1], MaxX: vals[2], MaxY: vals[3]}
+	return nil
+}
+
+// Value implements sql driver.Valuer interface
+func (b Box2D) Value() (driver.Value, error) {
+	return b.String(), nil
+}
+
+// Box3D presents PostGIS box3d, bounding box
+// of geometry, returned by ST_3DExtent
+type Box3D struct {
+	MinX, MinY, MinZ float64
+	MaxX, MaxY, MaxZ float64
+}
+
+// NewBox3D returns bounding box of all points of polygon.
+// Z bounds of polygon without Z dimension are zero.
+// Bounding box of empty polygon is zero
+func NewBox3D(p geo.Polygon, hasZ bool) Box3D {
+	var b Box3D
+	extendBox(&b, p, hasZ)
+	return b
+}
+
+// Box2D returns box without Z bounds
+func (b Box3D) Box2D() Box2D {
+	return Box2D{MinX: b.MinX, MinY: b.MinY, MaxX: b.MaxX, MaxY: b.MaxY}
+}
+
+// PolyhedralSurface returns envelope of box, created with geometry base,
+// as PostGIS does for box3d: six faces of box, oriented outwards.
+// Base must have Z dimension to keep Z bounds. M of points is zero.
+// Bounds of envelope are returned by Bounds of its View
+func (b Box3D) PolyhedralSurface(base Base) PolyhedralSurface {
+	dims := coordDims{known: true, z: base.HasZ(), m: base.HasM()}
+	xs, ys, zs := [2]float64{b.MinX, b.MaxX}, [2]float64{b.MinY, b.MaxY}, [2]float64{b.MinZ, b.MaxZ}
+
+	// faces hold indexes of X, Y and Z bounds of ring points
+	faces := [6][5][3]int{
+		{{0, 0, 0}, {0, 1, 0}, {1, 1, 0}, {1, 0, 0}, {0, 0, 0}},
+		{{0, 0, 0}, {0, 0, 1}, {0, 1, 1}, {0, 1, 0}, {0, 0, 0}},
+		{{0, 0, 0}, {1, 0, 0}, {1, 0, 1}, {0, 0, 1}, {0, 0, 0}},
+		{{1, 1, 1}, {1, 0, 1}, {0, 0, 1}, {0, 1, 1}, {1, 1, 1}},
+		{{1, 1, 1}, {0, 1, 1}, {0, 1, 0}, {1, 1, 0}, {1, 1, 1}},
+		{{1, 1, 1}, {1, 1, 0}, {1, 0, 0}, {1, 0, 1}, {1, 1, 1}},
+	}
+
+	pols := make([]geo.Polygon, len(faces))
+	for idx, face := range faces {
+		points := make([]geo.Point, len(face))
+		for pidx, c := range face {
+			points[pidx] = dims.point([]float64{xs[c[0]], ys[c[1]], zs[c[2]], 0})
+		}
+		pols[idx] = geo.NewPolygon([]geo.MultiPoint{geo.NewMultiPoint(points)})
+	}
+
+	return NewPolyhedralSurface(base, geo.NewMultiPolygon(pols))
+}
+
+// String returns box representation in PostGIS format
+func (b Box3D) String() string {
+	return "BOX3D(" + printCoords(b.MinX, b.MinY, b.MinZ) + "," +
+		printCoords(b.MaxX, b.MaxY, b.MaxZ) + ")"
+}
+
+// Scan implements sql.Scanner interface
+func (b *Box3D) Scan(src interface{}) error {
+	vals, err := scanBox(src, "BOX3D", 3)
+	if err != nil {
+		return err
+	}
+
+	*b = Box3D{
+		MinX: vals[0], MinY: vals[1], MinZ: vals[2],
+		MaxX: vals[3], MaxY: vals[4], MaxZ: vals[5],
+	}
+	return nil
+}
+
+// Value implements sql driver.Valuer interface
+func (b Box3D) Value() (driver.Value, error) {
+	return b.String(), nil
+}
+
+// NullBox2D presents nullable Box2D, like sql.Null does
+// for other types. ST_Extent returns NULL for empty set
+// of geometry objects, which can not be scanned into Box2D
+type NullBox2D struct {
+	Box Box2D
+	// Valid is true if box is not NULL
+	Valid bool
+}
+
+// Scan implements sql.Scanner interface
+func (n *NullBox2D) Scan(src interface{}) error {
+	*n = NullBox2D{}
+	if src == nil {
+		return nil
+	}
+
+	if err := n.Box.Scan(src); err != nil {
+		n.Box = Box2D{}
+		return err
+	}
+
+	n.Valid = true
+	return nil
+}
+
+// Value implements sql driver.Valuer interface
+func (n NullBox2D) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+
+	return n.Box.Value()
+}
+
+// NullBox3D presents nullable Box3D, like sql.Null does
+// for other types. ST_3DExtent returns NULL for empty set
+// of geometry objects, which can not be scanned into Box3D
+type NullBox3D struct {
+	Box Box3D
+	// Valid is true if box is not NULL
+	Valid bool
+}
+
+// Scan implements sql.Scanner interface
+func (n *NullBox3D) Scan(src interface{}) error {
+	*n = NullBox3D{}
+	if src == nil {
+		return nil
+	}
+
+	if err := n.Box.Scan(src); err != nil {
+		n.Box = Box3D{}
+		return err
+	}
+
+	n.Valid = true
+	return nil
+}
+
+// Value implements sql driver.Valuer interface
+func (n NullBox3D) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+
+	return n.Box.Value()
+}
+
+// extendBox sets bounds of box to bounds of points of polygon.
+// NaN coordinates are ignored
+func extendBox(b *Box3D, p geo.Polygon, hasZ bool) {
+	inf := math.Inf(1)
+	*b = Box3D{inf, inf, inf, -inf, -inf, -inf}
+	for idx := 0; idx < p.Len(); idx++ {
+		ring := p.Ring(idx)
+		for pidx := 0; pidx < ring.Len(); pidx++ {
+			pt := ring.Point(pidx)
+			z := math.NaN()
+			if hasZ {
+				z = pt.Z()
+			}
+			b.extend(pt.X(), pt.Y(), z)
+		}
+	}
+
+	b.finish()
+}
+
+// extend extends bounds of box, which is started with infinite
+// bounds, with coordinates of point. Point with NaN X or Y
+// is ignored, NaN Z does not extend Z bounds
+func (b *Box3D) extend(x, y, z float64) {
+	if math.IsNaN(x) || math.IsNaN(y) {
+		return
+	}
+
+	b.MinX, b.MaxX = math.Min(b.MinX, x), math.Max(b.MaxX, x)
+	b.MinY, b.MaxY = math.Min(b.MinY, y), math.Max(b.MaxY, y)
+	if !math.IsNaN(z) {
+		b.MinZ, b.MaxZ = math.Min(b.MinZ, z), math.Max(b.MaxZ, z)
+	}
+}
+
+// finish resets bounds, which were not extended, to zero
+func (b *Box3D) finish() {
+	if math.IsInf(b.MinX, 1) {
+		*b = Box3D{}
+	}
+	if math.IsInf(b.MinZ, 1) {
+		b.MinZ, b.MaxZ = 0, 0
+	}
+}
+
+// scanBox parses box in PostGIS format with specified tag
+// and returns values of lower and upper corners of box
+func scanBox(src interface{}, tag string, dims int) ([]float64, error) {
+	var s string
+	switch d := src.(type) {
+	case []byte:
+		s = string(d)
+	case string:
+		s = d
+	default:
+		return nil, fmt.Errorf("could not scan box: unsupported source type %T", src)
+	}
+
+	s = strings.TrimSpace(s)
+	if len(s) < len(tag)+2 || !strings.EqualFold(s[:len(tag)+1], tag+"(") || s[len(s)-1] != ')' {
+		return nil, fmt.Errorf("could not scan box: invalid value %q", s)
+	}
+
+	corners := strings.Split(s[len(tag)+1:len(s)-1], ",")
+	if len(corners) != 2 {
+		return nil, fmt.Errorf("could not scan box: invalid value %q", s)
+	}
+
+	vals := make([]float64, 0, dims*2)
+	for _, corner := range corners {
+		fields := strings.Fields(corner)
+		if len(fields) != dims {
+			return nil, fmt.Errorf("could not scan box: invalid value %q", s)
+		}
+
+		for _, f := range fields {
+			v, err := strconv.ParseFloat(f, 64)
+			if err != nil {
+				return nil, fmt.Errorf("could not scan box: %w", err)
+			}
+			vals = append(vals, v)
+		}
+	}
+
+	return vals, nil
+}
+
+// printCoords returns coordinates, separated by spaces
+func printCoords(vals ...float64) string {
+	s := make([]string, len(vals))
+	for idx, v := range vals {
+		s[idx] = strconv.FormatFloat(v, 'f', -1, 64)
+	}
+
+	return strings.Join(s, " ")
+}
@@ -0,0 +1,201 @@
+package ewkb
+
+import (
+	"testing"
+
+	"github.com/kcasctiv/go-ewkb/geo"
+)
+
+func TestBox2D_Scan(t *testing.T) {
+	cases := []struct {
+		name     string
+		src      interface{}
+		expected Box2D
+	}{
+		{"string", "BOX(1 2,3 4)", Box2D{1, 2, 3, 4}},
+		{"bytes", []byte("BOX(-1.5 2,3 4.25)"), Box2D{-1.5, 2, 3, 4.25}},
+		{"spaces", " box( 1 2 , 3 4 ) ", Box2D{1, 2, 3, 4}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var b Box2D
+			if err := b.Scan(c.src); err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			if b != c.expected {
+				t.Errorf("Expected %v, got %v\n", c.expected, b)
+			}
+		})
+	}
+}
+
+func TestBox3D_Scan(t *testing.T) {
+	var b Box3D
+	if err := b.Scan("BOX3D(1 2 3,4 5 6)"); err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	expected := Box3D{1, 2, 3, 4, 5, 6}
+	if b != expected {
+		t.Errorf("Expected %v, got %v\n", expected, b)
+	}
+
+	if s := b.String(); s != "BOX3D(1 2 3,4 5 6)" {
+		t.Errorf("Expected %q, got %q\n", "BOX3D(1 2 3,4 5 6)", s)
+	}
+
+	if b2 := b.Box2D(); b2 != (Box2D{1, 2, 4, 5}) {
+		t.Errorf("Expected %v, got %v\n", Box2D{1, 2, 4, 5}, b2)
+	}
+}
+
+func TestBox_Scan_Invalid(t *testing.T) {
+	cases := []interface{}{
+		nil,
+		1,
+		"BOX(1 2,3)",
+		"BOX(1 2 3,4 5 6)",
+		"BOX3D(1 2,3 4)",
+		"BOX(1 2,3 4",
+		"POINT(1 2)",
+		"BOX(a 2,3 4)",
+	}
+
+	for _, src := range cases {
+		var b Box2D
+		if err := b.Scan(src); err == nil {
+			t.Errorf("Expected: error, got: no errors (%v)\n", src)
+		}
+	}
+
+	var b Box3D
+	if err := b.Scan("BOX(1 2,3 4)"); err == nil {
+		t.Error("Expected: error, got: no errors\n")
+	}
+}
+
+func TestNullBox_Scan(t *testing.T) {
+	n2 := NullBox2D{Box: Box2D{1, 2, 3, 4}, Valid: true}
+	if err := n2.Scan(nil); err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	if n2 != (NullBox2D{}) {
+		t.Errorf("Expected %v, got %v\n", NullBox2D{}, n2)
+	}
+
+	if v, err := n2.Value(); err != nil || v != nil {
+		t.Errorf("Expected %v, got %v (%v)\n", nil, v, err)
+	}
+
+	if err := n2.Scan("BOX(1 2,3 4)"); err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	expected2 := NullBox2D{Box: Box2D{1, 2, 3, 4}, Valid: true}
+	if n2 != expected2 {
+		t.Errorf("Expected %v, got %v\n", expected2, n2)
+	}
+
+	if err := n2.Scan("BOX(1 2,3)"); err == nil {
+		t.Error("Expected: error, got: no errors\n")
+	}
+
+	if n2 != (NullBox2D{}) {
+		t.Errorf("Expected %v, got %v\n", NullBox2D{}, n2)
+	}
+
+	n3 := NullBox3D{Box: Box3D{1, 2, 3, 4, 5, 6}, Valid: true}
+	if err := n3.Scan(nil); err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	if n3 != (NullBox3D{}) {
+		t.Errorf("Expected %v, got %v\n", NullBox3D{}, n3)
+	}
+
+	if err := n3.Scan([]byte("BOX3D(1 2 3,4 5 6)")); err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	v, err := n3.Value()
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	if v != "BOX3D(1 2 3,4 5 6)" {
+		t.Errorf("Expected %q, got %v\n", "BOX3D(1 2 3,4 5 6)", v)
+	}
+}
+
+func TestBox2D_Value(t *testing.T) {
+	v, err := Box2D{1, 2.5, 3, 4}.Value()
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	if v != "BOX(1 2.5,3 4)" {
+		t.Errorf("Expected %q, got %q\n", "BOX(1 2.5,3 4)", v)
+	}
+}
+
+func TestBox2D_Polygon(t *testing.T) {
+	b := Box2D{1, 2, 3, 4}
+	p := b.Polygon(NewBase(NDR, false, false, true, 4326))
+	expected := "SRID=4326;POLYGON((1 2,1 4,3 4,3 2,1 2))"
+	if s := p.String(); s != expected {
+		t.Errorf("Expected %q, got %q\n", expected, s)
+	}
+
+	if nb := NewBox2D(&p); nb != b {
+		t.Errorf("Expected %v, got %v\n", b, nb)
+	}
+}
+
+func TestBox3D_PolyhedralSurface(t *testing.T) {
+	b := Box3D{0, 1, 2, 3, 4, 5}
+	ps := b.PolyhedralSurface(NewBase(NDR, true, false, false, 0))
+	expected := "POLYHEDRALSURFACE(((0 1 2,0 4 2,3 4 2,3 1 2,0 1 2))," +
+		"((0 1 2,0 1 5,0 4 5,0 4 2,0 1 2)),((0 1 2,3 1 2,3 1 5,0 1 5,0 1 2))," +
+		"((3 4 5,3 1 5,0 1 5,0 4 5,3 4 5)),((3 4 5,0 4 5,0 4 2,3 4 2,3 4 5))," +
+		"((3 4 5,3 4 2,3 1 2,3 1 5,3 4 5)))"
+	if s := ps.String(); s != expected {
+		t.Errorf("Expected %q, got %q\n", expected, s)
+	}
+
+	data, err := ps.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	v, err := NewView(data, DecodeOptions{})
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	if nb := v.Bounds(); nb != b {
+		t.Errorf("Expected %v, got %v\n", b, nb)
+	}
+}
+
+func TestNewBox3D(t *testing.T) {
+	poly := geo.NewPolygon([]geo.MultiPoint{geo.NewMultiPoint([]geo.Point{
+		geo.NewPointZ(0, 5, 1), geo.NewPointZ(2, -1, 7), geo.NewPointZ(1, 3, -2), geo.NewPointZ(0, 5, 1),
+	})})
+
+	expected := Box3D{0, -1, -2, 2, 5, 7}
+	if b := NewBox3D(poly, true); b != expected {
+		t.Errorf("Expected %v, got %v\n", expected, b)
+	}
+
+	expected = Box3D{0, -1, 0, 2, 5, 0}
+	if b := NewBox3D(poly, false); b != expected {
+		t.Errorf("Expected %v, got %v\n", expected, b)
+	}
+
+	if b := NewBox2D(geo.NewPolygon(nil)); b != (Box2D{}) {
+		t.Errorf("Expected %v, got %v\n", Box2D{}, b)
+	}
+}
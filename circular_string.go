@@ -0,0 +1,118 @@
+package ewkb
+
+import (
+	"database/sql/driver"
+
+	"github.com/kcasctiv/go-ewkb/geo"
+)
+
+// CircularString presents CircularString geometry object,
+// consisting of circular arcs, every one of which is defined
+// by start, intermediate and end points. End point of arc
+// is start point of the next one
+type CircularString struct {
+	header
+	mp geo.MultiPoint
+}
+
+// NewCircularString returns new CircularString,
+// created from geometry base and coords data
+func NewCircularString(b Base, mp geo.MultiPoint) CircularString {
+	return CircularString{
+		header: header{
+			byteOrder: b.ByteOrder(),
+			wkbType: getFlags(
+				b.HasZ(),
+				b.HasM(),
+				b.HasSRID(),
+			) | CircularStringType,
+			srid: b.SRID(),
+		},
+		mp: mp,
+	}
+}
+
+// NewEmptyCircularString returns new empty CircularString,
+// created from geometry base
+func NewEmptyCircularString(b Base) CircularString {
+	return NewCircularString(b, geo.NewMultiPoint(nil))
+}
+
+// Point returns point of CircularString with specified index
+func (c *CircularString) Point(idx int) geo.Point { return c.mp.Point(idx) }
+
+// Len returns length of CircularString (count of points)
+func (c *CircularString) Len() int { return c.mp.Len() }
+
+// IsEmpty checks if CircularString has no points
+func (c *CircularString) IsEmpty() bool { return c.Len() == 0 }
+
+// Validate checks that dimensions of all points
+// and members match dimensions of geometry
+func (c *CircularString) Validate() error { return validateGeometry(c) }
+
+// String returns WKT/EWKT geometry representation
+func (c *CircularString) String() string { return sridPrefix(c) + c.wkt() }
+
+// wkt returns WKT geometry representation
+func (c *CircularString) wkt() string {
+	s := "CIRCULARSTRING" + wktDims(c)
+
+	if c.Len() == 0 {
+		return s + " EMPTY"
+	}
+
+	return s + printMultiPoint(c, c.HasZ(), c.HasM())
+}
+
+// Scan implements sql.Scanner interface
+func (c *CircularString) Scan(src interface{}) error {
+	return scanGeometry(src, c)
+}
+
+// Value implements sql driver.Valuer interface
+func (c *CircularString) Value() (driver.Value, error) {
+	return geometryValue(c, DefaultValueFormat)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler interface
+func (c *CircularString) UnmarshalBinary(data []byte) error {
+	g, err := unmarshal(data, CircularStringType, DecodeOptions{})
+	if err != nil {
+		return err
+	}
+
+	*c = *g.(*CircularString)
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler interface
+func (c *CircularString) MarshalBinary() ([]byte, error) {
+	return c.appendBinary(nil, EncodeOptions{})
+}
+
+// AppendBinary appends binary representation of geometry to dst
+// and returns extended slice. Implements encoding.BinaryAppender interface
+func (c *CircularString) AppendBinary(dst []byte) ([]byte, error) {
+	return c.appendBinary(dst, EncodeOptions{})
+}
+
+// EncodedSize returns size of binary representation of geometry
+func (c *CircularString) EncodedSize() int {
+	return c.encodedSize(EncodeOptions{})
+}
+
+func (c *CircularString) encodedSize(opts EncodeOptions) int {
+	return headerSize(c.HasSRID() && !opts.ISO) + multiPointSize(c, c.HasZ(), c.HasM())
+}
+
+func (c *CircularString) appendBinary(dst []byte, opts EncodeOptions) ([]byte, error) {
+	hasSRID := c.HasSRID() && !opts.ISO
+	dst, b := grow(dst, c.encodedSize(opts))
+
+	byteOrder := getBinaryByteOrder(c.ByteOrder())
+	offset := writeHeader(c, c.Type(), byteOrder, hasSRID, opts, b)
+	writeMultiPoint(c, byteOrder, c.HasZ(), c.HasM(), b[offset:])
+
+	return dst, nil
+}
@@ -0,0 +1,121 @@
+package ewkb
+
+import "database/sql/driver"
+
+// CompoundCurve presents CompoundCurve geometry object,
+// consisting of connected LineString
+// and CircularString segments
+type CompoundCurve struct {
+	header
+	curves []Geometry
+}
+
+// NewCompoundCurve returns new CompoundCurve,
+// created from geometry base and segments.
+// Segments must match base, as members of GeometryCollection
+func NewCompoundCurve(b Base, curves []Geometry) CompoundCurve {
+	return CompoundCurve{
+		header: header{
+			byteOrder: b.ByteOrder(),
+			wkbType: getFlags(
+				b.HasZ(),
+				b.HasM(),
+				b.HasSRID(),
+			) | CompoundCurveType,
+			srid: b.SRID(),
+		},
+		curves: curves,
+	}
+}
+
+// NewEmptyCompoundCurve returns new empty CompoundCurve,
+// created from geometry base
+func NewEmptyCompoundCurve(b Base) CompoundCurve {
+	return NewCompoundCurve(b, nil)
+}
+
+// Curve returns segment with specified index
+func (c *CompoundCurve) Curve(idx int) Geometry { return c.curves[idx] }
+
+// Len returns count of segments
+func (c *CompoundCurve) Len() int { return len(c.curves) }
+
+// IsEmpty checks if CompoundCurve has no curves or all its curves are empty
+func (c *CompoundCurve) IsEmpty() bool { return isEmptyGeometries(c.curves) }
+
+// Validate checks that dimensions of all points
+// and members match dimensions of geometry
+func (c *CompoundCurve) Validate() error { return validateGeometry(c) }
+
+// String returns WKT/EWKT geometry representation
+func (c *CompoundCurve) String() string { return sridPrefix(c) + c.wkt() }
+
+// wkt returns WKT geometry representation
+func (c *CompoundCurve) wkt() string {
+	return "COMPOUNDCURVE" + wktDims(c) + printMembers(c.curves, LineType)
+}
+
+// Scan implements sql.Scanner interface
+func (c *CompoundCurve) Scan(src interface{}) error {
+	return scanGeometry(src, c)
+}
+
+// Value implements sql driver.Valuer interface
+func (c *CompoundCurve) Value() (driver.Value, error) {
+	return geometryValue(c, DefaultValueFormat)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler interface
+func (c *CompoundCurve) UnmarshalBinary(data []byte) error {
+	g, err := unmarshal(data, CompoundCurveType, DecodeOptions{})
+	if err != nil {
+		return err
+	}
+
+	*c = *g.(*CompoundCurve)
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler interface
+func (c *CompoundCurve) MarshalBinary() ([]byte, error) {
+	return c.appendBinary(nil, EncodeOptions{})
+}
+
+// AppendBinary appends binary representation of geometry to dst
+// and returns extended slice. Implements encoding.BinaryAppender interface
+func (c *CompoundCurve) AppendBinary(dst []byte) ([]byte, error) {
+	return c.appendBinary(dst, EncodeOptions{})
+}
+
+// EncodedSize returns size of binary representation of geometry
+func (c *CompoundCurve) EncodedSize() int {
+	return c.encodedSize(EncodeOptions{})
+}
+
+func (c *CompoundCurve) encodedSize(opts EncodeOptions) int {
+	// members of unsupported types are rejected by encoding
+	size, _ := collectionSize(c.curves)
+	return headerSize(c.HasSRID() && !opts.ISO) + size
+}
+
+func (c *CompoundCurve) appendBinary(dst []byte, opts EncodeOptions) ([]byte, error) {
+	if err := validateMembers(c); err != nil {
+		return dst, err
+	}
+
+	size, err := collectionSize(c.curves)
+	if err != nil {
+		return dst, err
+	}
+
+	hasSRID := c.HasSRID() && !opts.ISO
+	out, b := grow(dst, headerSize(hasSRID)+size)
+
+	byteOrder := getBinaryByteOrder(c.ByteOrder())
+	offset := writeHeader(c, c.Type(), byteOrder, hasSRID, opts, b)
+	if _, err = writeCollection(c.curves, byteOrder, opts, b[offset:]); err != nil {
+		return dst, err
+	}
+
+	return out, nil
+}
@@ -0,0 +1,136 @@
+package ewkb
+
+import "github.com/kcasctiv/go-ewkb/geo"
+
+// container holds name and allowed types of members
+// of geometry, which consists of other geometry objects
+type container struct {
+	name  string
+	types []uint32
+	// strict requires members to have dimensions of geometry
+	// and either no SRID or SRID of geometry. Members of strict
+	// containers keep their headers and are encoded with their
+	// own dimensions, but without SRID. Dimensions of members
+	// of other containers are checked by decoders only, as they
+	// are encoded with dimensions of geometry
+	strict bool
+}
+
+// containers holds members of geometry types,
+// which consist of other geometry objects.
+// Nil types allow members of any type
+var containers = map[uint32]container{
+	MultiPointType:        {"point", []uint32{PointType}, false},
+	MultiLineType:         {"line", []uint32{LineType}, false},
+	MultiPolygonType:      {"polygon", []uint32{PolygonType}, false},
+	CollectionType:        {"geometry", nil, true},
+	CompoundCurveType:     {"curve", []uint32{LineType, CircularStringType}, true},
+	CurvePolygonType:      {"ring", []uint32{LineType, CircularStringType, CompoundCurveType}, true},
+	MultiCurveType:        {"curve", []uint32{LineType, CircularStringType, CompoundCurveType}, true},
+	MultiSurfaceType:      {"surface", []uint32{PolygonType, CurvePolygonType}, true},
+	PolyhedralSurfaceType: {"polygon", []uint32{PolygonType}, false},
+	TinType:               {"triangle", []uint32{TriangleType}, false},
+}
+
+// checkType returns TypeMismatchError,
+// if member of type typ is not allowed
+func (c container) checkType(typ uint32) error {
+	if c.types == nil {
+		return nil
+	}
+
+	for _, t := range c.types {
+		if t == typ {
+			return nil
+		}
+	}
+
+	return &TypeMismatchError{Expected: c.types[0], Actual: typ}
+}
+
+// checkMember returns DimensionMismatchError or SRIDMismatchError,
+// if container is strict and member with header h does not match
+// header of geometry parent
+func (c container) checkMember(parent, h header) error {
+	if !c.strict {
+		return nil
+	}
+
+	if err := checkDims(parent, h); err != nil {
+		return err
+	}
+
+	if h.HasSRID() && (!parent.HasSRID() || h.SRID() != parent.SRID()) {
+		var srid int32
+		if parent.HasSRID() {
+			srid = parent.SRID()
+		}
+
+		return &SRIDMismatchError{Expected: srid, Actual: h.SRID()}
+	}
+
+	return nil
+}
+
+// checkDims returns DimensionMismatchError,
+// if dimensions of member with header h differ
+// from dimensions of geometry parent
+func checkDims(parent, h header) error {
+	if h.HasZ() != parent.HasZ() || h.HasM() != parent.HasM() {
+		return &DimensionMismatchError{
+			Expected: geo.NewLayout(parent.HasZ(), parent.HasM()),
+			Actual:   geo.NewLayout(h.HasZ(), h.HasM()),
+		}
+	}
+
+	return nil
+}
+
+// printMembers returns WKT representation of members
+// of curved geometry. Members of bare type are printed
+// without type name, as required by WKT
+func printMembers(geoms []Geometry, bare uint32) string {
+	if len(geoms) == 0 {
+		return " EMPTY"
+	}
+
+	s := "("
+	for idx, geom := range geoms {
+		if idx > 0 {
+			s += ","
+		}
+
+		switch g := geom.(type) {
+		case *LineString:
+			if bare == LineType {
+				s += printMultiPoint(g, g.HasZ(), g.HasM())
+				continue
+			}
+		case *Polygon:
+			if bare == PolygonType {
+				s += printPolygon(g, g.HasZ(), g.HasM())
+				continue
+			}
+		}
+
+		s += memberString(geom)
+	}
+
+	return s + ")"
+}
+
+// memberString returns WKT representation of member
+// of geometry. SRID is specified only for the whole geometry,
+// so members are printed without it. Nil member, which fails
+// validation, is printed as <nil>
+func memberString(g Geometry) string {
+	if g == nil {
+		return "<nil>"
+	}
+
+	if w, ok := g.(interface{ wkt() string }); ok {
+		return w.wkt()
+	}
+
+	return g.String()
+}
@@ -0,0 +1,121 @@
+package ewkb
+
+import "database/sql/driver"
+
+// CurvePolygon presents CurvePolygon geometry object,
+// rings of which are LineString, CircularString
+// or CompoundCurve geometry objects
+type CurvePolygon struct {
+	header
+	rings []Geometry
+}
+
+// NewCurvePolygon returns new CurvePolygon,
+// created from geometry base and rings.
+// Rings must match base, as members of GeometryCollection
+func NewCurvePolygon(b Base, rings []Geometry) CurvePolygon {
+	return CurvePolygon{
+		header: header{
+			byteOrder: b.ByteOrder(),
+			wkbType: getFlags(
+				b.HasZ(),
+				b.HasM(),
+				b.HasSRID(),
+			) | CurvePolygonType,
+			srid: b.SRID(),
+		},
+		rings: rings,
+	}
+}
+
+// NewEmptyCurvePolygon returns new empty CurvePolygon,
+// created from geometry base
+func NewEmptyCurvePolygon(b Base) CurvePolygon {
+	return NewCurvePolygon(b, nil)
+}
+
+// Ring returns ring with specified index
+func (p *CurvePolygon) Ring(idx int) Geometry { return p.rings[idx] }
+
+// Len returns count of rings
+func (p *CurvePolygon) Len() int { return len(p.rings) }
+
+// IsEmpty checks if CurvePolygon has no rings or all its rings are empty
+func (p *CurvePolygon) IsEmpty() bool { return isEmptyGeometries(p.rings) }
+
+// Validate checks that dimensions of all points
+// and members match dimensions of geometry
+func (p *CurvePolygon) Validate() error { return validateGeometry(p) }
+
+// String returns WKT/EWKT geometry representation
+func (p *CurvePolygon) String() string { return sridPrefix(p) + p.wkt() }
+
+// wkt returns WKT geometry representation
+func (p *CurvePolygon) wkt() string {
+	return "CURVEPOLYGON" + wktDims(p) + printMembers(p.rings, LineType)
+}
+
+// Scan implements sql.Scanner interface
+func (p *CurvePolygon) Scan(src interface{}) error {
+	return scanGeometry(src, p)
+}
+
+// Value implements sql driver.Valuer interface
+func (p *CurvePolygon) Value() (driver.Value, error) {
+	return geometryValue(p, DefaultValueFormat)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler interface
+func (p *CurvePolygon) UnmarshalBinary(data []byte) error {
+	g, err := unmarshal(data, CurvePolygonType, DecodeOptions{})
+	if err != nil {
+		return err
+	}
+
+	*p = *g.(*CurvePolygon)
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler interface
+func (p *CurvePolygon) MarshalBinary() ([]byte, error) {
+	return p.appendBinary(nil, EncodeOptions{})
+}
+
+// AppendBinary appends binary representation of geometry to dst
+// and returns extended slice. Implements encoding.BinaryAppender interface
+func (p *CurvePolygon) AppendBinary(dst []byte) ([]byte, error) {
+	return p.appendBinary(dst, EncodeOptions{})
+}
+
+// EncodedSize returns size of binary representation of geometry
+func (p *CurvePolygon) EncodedSize() int {
+	return p.encodedSize(EncodeOptions{})
+}
+
+func (p *CurvePolygon) encodedSize(opts EncodeOptions) int {
+	// members of unsupported types are rejected by encoding
+	size, _ := collectionSize(p.rings)
+	return headerSize(p.HasSRID() && !opts.ISO) + size
+}
+
+func (p *CurvePolygon) appendBinary(dst []byte, opts EncodeOptions) ([]byte, error) {
+	if err := validateMembers(p); err != nil {
+		return dst, err
+	}
+
+	size, err := collectionSize(p.rings)
+	if err != nil {
+		return dst, err
+	}
+
+	hasSRID := p.HasSRID() && !opts.ISO
+	out, b := grow(dst, headerSize(hasSRID)+size)
+
+	byteOrder := getBinaryByteOrder(p.ByteOrder())
+	offset := writeHeader(p, p.Type(), byteOrder, hasSRID, opts, b)
+	if _, err = writeCollection(p.rings, byteOrder, opts, b[offset:]); err != nil {
+		return dst, err
+	}
+
+	return out, nil
+}
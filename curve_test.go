@@ -0,0 +1,350 @@
+package ewkb
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/kcasctiv/go-ewkb/geo"
+)
+
+var curveGeoms = []string{
+	"CIRCULARSTRING(0 0,1 1,2 0)",
+	"SRID=4326;CIRCULARSTRING EMPTY",
+	"CIRCULARSTRINGM(0 0 1,1 1 2,2 0 3)",
+	"COMPOUNDCURVE((0 0,1 0),CIRCULARSTRING(1 0,2 1,3 0))",
+	"COMPOUNDCURVE EMPTY",
+	"CURVEPOLYGON(CIRCULARSTRING(0 0,2 0,0 0),(0.5 0.5,1 0.5,1 1,0.5 0.5))",
+	"CURVEPOLYGON(COMPOUNDCURVE(CIRCULARSTRING(0 0,1 1,2 0),(2 0,0 0)))",
+	"MULTICURVE((0 0,5 5),CIRCULARSTRING(4 0,4 4,8 4))",
+	"MULTISURFACE(CURVEPOLYGON(CIRCULARSTRING(0 0,2 0,0 0)),((5 5,5 6,6 6,5 5)))",
+	"GEOMETRYCOLLECTION(POINT(1 2),CIRCULARSTRING(0 0,1 1,2 0))",
+}
+
+func TestCurves_String(t *testing.T) {
+	for _, s := range curveGeoms {
+		t.Run(s, func(t *testing.T) {
+			g, err := ParseEWKT(s)
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			if gs := g.String(); gs != s {
+				t.Errorf("Expected %q, got %q\n", s, gs)
+			}
+		})
+	}
+}
+
+func TestCurves_Binary(t *testing.T) {
+	for _, s := range curveGeoms {
+		t.Run(s, func(t *testing.T) {
+			g, err := ParseEWKT(s)
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			data, err := g.MarshalBinary()
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			if size := g.EncodedSize(); size != len(data) {
+				t.Errorf("EncodedSize: expected %v, got %v\n", len(data), size)
+			}
+
+			var w Wrapper
+			if err = w.Scan(data); err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			if gs := w.Geometry.String(); gs != s {
+				t.Errorf("Expected %q, got %q\n", s, gs)
+			}
+
+			v, err := NewView(data, DecodeOptions{})
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			if v.Type() != g.Type() {
+				t.Errorf("Type: expected %v, got %v\n", g.Type(), v.Type())
+			}
+		})
+	}
+}
+
+func TestCircularString_UnmarshalBinary(t *testing.T) {
+	data := []byte{
+		1, 8, 0, 0, 0, 3, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 240, 63, 0, 0, 0, 0, 0, 0, 240, 63,
+		0, 0, 0, 0, 0, 0, 0, 64, 0, 0, 0, 0, 0, 0, 0, 0,
+	}
+
+	var c CircularString
+	if err := c.UnmarshalBinary(data); err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	if s := c.String(); s != "CIRCULARSTRING(0 0,1 1,2 0)" {
+		t.Errorf("Expected %q, got %q\n", "CIRCULARSTRING(0 0,1 1,2 0)", s)
+	}
+}
+
+func TestCurves_MemberTypeMismatch(t *testing.T) {
+	if _, err := ParseEWKT("COMPOUNDCURVE(POINT(1 2))"); err == nil {
+		t.Error("Expected: error, got: no errors\n")
+	}
+
+	// CompoundCurve with Point member
+	data := []byte{
+		1, 9, 0, 0, 0, 1, 0, 0, 0, 1, 1, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	}
+	if _, err := Unmarshal(data, DecodeOptions{}); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("Expected %v, got %v\n", ErrTypeMismatch, err)
+	}
+	if _, err := NewView(data, DecodeOptions{}); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("Expected %v, got %v\n", ErrTypeMismatch, err)
+	}
+}
+
+func TestCurves_AppendBinary_MemberMismatch(t *testing.T) {
+	line, err := ParseEWKT("LINESTRING(0 0,1 1,0 0)")
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	poly, err := ParseEWKT("POLYGON((0 0,1 1,1 0,0 0))")
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	base := NewBase(NDR, false, false, false, 0)
+	members := []Geometry{Force3DZ(line, 1)}
+	compound := NewCompoundCurve(base, members)
+	curvePoly := NewCurvePolygon(base, members)
+	multiCurve := NewMultiCurve(base, members)
+	multiSurface := NewMultiSurface(base, []Geometry{Force3DZ(poly, 1)})
+	collection := NewGeometryCollection(base, members)
+	for _, g := range []Geometry{&compound, &curvePoly, &multiCurve, &multiSurface, &collection} {
+		dst := []byte{1, 2, 3}
+		b, err := g.AppendBinary(dst)
+		if !errors.Is(err, ErrDimensionMismatch) {
+			t.Errorf("Expected %v, got %v\n", ErrDimensionMismatch, err)
+		}
+
+		if string(b) != string(dst) {
+			t.Errorf("Expected %v, got %v\n", dst, b)
+		}
+	}
+}
+
+func TestCurves_MarshalBinary_MemberTypeMismatch(t *testing.T) {
+	base := NewBase(NDR, false, false, false, 0)
+	point := NewPoint(base, geo.NewPoint(1, 2))
+	line := NewLineString(base, geo.NewMultiPoint([]geo.Point{geo.NewPoint(0, 0), geo.NewPoint(1, 1)}))
+	poly := NewPolygon(base, geo.NewPolygon(nil))
+	compound := NewCompoundCurve(base, []Geometry{&point})
+	curvePoly := NewCurvePolygon(base, []Geometry{&poly})
+	multiCurve := NewMultiCurve(base, []Geometry{&line, &poly})
+	multiSurface := NewMultiSurface(base, []Geometry{&line})
+	cases := []struct {
+		name string
+		geom Geometry
+		msg  string
+	}{
+		{"compound curve", &compound, "(curve 0)"},
+		{"curve polygon", &curvePoly, "(ring 0)"},
+		{"multicurve", &multiCurve, "(curve 1)"},
+		{"multisurface", &multiSurface, "(surface 0)"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if err := c.geom.Validate(); !errors.Is(err, ErrTypeMismatch) {
+				t.Errorf("Expected %v, got %v\n", ErrTypeMismatch, err)
+			}
+
+			_, err := c.geom.MarshalBinary()
+			if !errors.Is(err, ErrTypeMismatch) {
+				t.Fatalf("Expected %v, got %v\n", ErrTypeMismatch, err)
+			}
+
+			if !strings.HasSuffix(err.Error(), c.msg) {
+				t.Errorf("Expected %q, got %q\n", c.msg, err.Error())
+			}
+		})
+	}
+}
+
+func TestLinearize(t *testing.T) {
+	h := math.Sqrt2 / 2
+	cases := []struct {
+		name     string
+		geom     string
+		typ      uint32
+		expected [][]float64
+	}{
+		{
+			"circular string",
+			"CIRCULARSTRING(0 0,1 1,2 0)",
+			LineType,
+			[][]float64{{0, 0}, {1 - h, h}, {1, 1}, {1 + h, h}, {2, 0}},
+		},
+		{
+			"counterclockwise",
+			"CIRCULARSTRING(2 0,1 -1,0 0)",
+			LineType,
+			[][]float64{{2, 0}, {1 + h, -h}, {1, -1}, {1 - h, -h}, {0, 0}},
+		},
+		{
+			"collinear",
+			"CIRCULARSTRING(0 0,1 1,2 2)",
+			LineType,
+			[][]float64{{0, 0}, {1, 1}, {2, 2}},
+		},
+		{
+			"compound curve",
+			"COMPOUNDCURVE((-1 0,0 0),CIRCULARSTRING(0 0,1 1,2 0))",
+			LineType,
+			[][]float64{{-1, 0}, {0, 0}, {1 - h, h}, {1, 1}, {1 + h, h}, {2, 0}},
+		},
+		{
+			"M interpolation",
+			"CIRCULARSTRINGM(0 0 0,1 1 2,2 0 4)",
+			LineType,
+			[][]float64{{0, 0, 0}, {1 - h, h, 1}, {1, 1, 2}, {1 + h, h, 3}, {2, 0, 4}},
+		},
+		{
+			"full circle",
+			"CURVEPOLYGON(CIRCULARSTRING(0 0,2 0,0 0))",
+			PolygonType,
+			[][]float64{{0, 0}, {1 - h, -h}, {1, -1}, {1 + h, -h}, {2, 0}, {1 + h, h}, {1, 1}, {1 - h, h}, {0, 0}},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			g, err := ParseEWKT(c.geom)
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			lg, err := Linearize(g, 2)
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			if lg.Type() != c.typ {
+				t.Fatalf("Type: expected %v, got %v\n", c.typ, lg.Type())
+			}
+
+			var points geo.MultiPoint
+			switch l := lg.(type) {
+			case *LineString:
+				points = l
+			case *Polygon:
+				points = l.Ring(0)
+			}
+
+			if points.Len() != len(c.expected) {
+				t.Fatalf("Len: expected %v, got %v (%v)\n", len(c.expected), points.Len(), lg)
+			}
+
+			for idx, e := range c.expected {
+				p := points.Point(idx)
+				vals := []float64{p.X(), p.Y()}
+				if lg.HasM() {
+					vals = append(vals, p.M())
+				}
+
+				for v := range e {
+					if math.Abs(vals[v]-e[v]) > 1e-9 {
+						t.Errorf("Point %d: expected %v, got %v\n", idx, e, vals)
+						break
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestLinearize_FarFromOrigin(t *testing.T) {
+	g, err := ParseEWKT("CIRCULARSTRING(30 50,30.000001 50.000001,30.000002 50)")
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	lg, err := Linearize(g, 8)
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	line := lg.(*LineString)
+	if line.Len() != 17 {
+		t.Fatalf("Len: expected %v, got %v\n", 17, line.Len())
+	}
+
+	// Circle has center (30.000001 50) and radius 1e-6
+	for idx := 0; idx < line.Len(); idx++ {
+		p := line.Point(idx)
+		r := math.Hypot(p.X()-30.000001, p.Y()-50)
+		if math.Abs(r-1e-6) > 1e-6*1e-6 {
+			t.Errorf("Point %d: expected radius %v, got %v\n", idx, 1e-6, r)
+		}
+	}
+}
+
+func TestLinearize_Invalid(t *testing.T) {
+	g, err := ParseEWKT("CIRCULARSTRING(0 0,1 1)")
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	if _, err = Linearize(g, 2); err == nil {
+		t.Error("Expected: error, got: no errors\n")
+	}
+
+	if _, err = Linearize(g, 0); err == nil {
+		t.Error("Expected: error, got: no errors\n")
+	}
+}
+
+func TestLinearize_Types(t *testing.T) {
+	cases := []struct {
+		geom     string
+		expected string
+	}{
+		{"POINT(1 2)", "POINT(1 2)"},
+		{"MULTICURVE((0 0,1 1),CIRCULARSTRING(0 0,1 1,2 2))", "MULTILINESTRING((0 0,1 1),(0 0,1 1,2 2))"},
+		{
+			"MULTISURFACE(((5 5,5 6,6 6,5 5)),CURVEPOLYGON((0 0,1 1,1 0,0 0)))",
+			"MULTIPOLYGON(((5 5,5 6,6 6,5 5)),((0 0,1 1,1 0,0 0)))",
+		},
+		{
+			"SRID=4326;GEOMETRYCOLLECTION(POINT(1 2),COMPOUNDCURVE((0 0,1 1),(1 1,2 2)))",
+			"SRID=4326;GEOMETRYCOLLECTION(POINT(1 2),LINESTRING(0 0,1 1,2 2))",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.geom, func(t *testing.T) {
+			g, err := ParseEWKT(c.geom)
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			lg, err := Linearize(g, 4)
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			if s := lg.String(); s != c.expected {
+				t.Errorf("Expected %q, got %q\n", c.expected, s)
+			}
+		})
+	}
+}
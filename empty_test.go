@@ -0,0 +1,182 @@
+package ewkb
+
+import (
+	"encoding/hex"
+	"math"
+	"testing"
+
+	"github.com/kcasctiv/go-ewkb/geo"
+)
+
+func TestNewEmpty(t *testing.T) {
+	b := NewBase(NDR, false, false, false, 0)
+	bz := NewBase(NDR, true, false, true, 4326)
+	var (
+		point              = NewEmptyPoint(b)
+		pointZ             = NewEmptyPoint(bz)
+		lineString         = NewEmptyLineString(b)
+		polygon            = NewEmptyPolygon(b)
+		multiPoint         = NewEmptyMultiPoint(b)
+		multiLineString    = NewEmptyMultiLineString(b)
+		multiPolygon       = NewEmptyMultiPolygon(b)
+		geometryCollection = NewEmptyGeometryCollection(b)
+		circularString     = NewEmptyCircularString(b)
+		compoundCurve      = NewEmptyCompoundCurve(b)
+		curvePolygon       = NewEmptyCurvePolygon(b)
+		multiCurve         = NewEmptyMultiCurve(b)
+		multiSurface       = NewEmptyMultiSurface(b)
+		polyhedralSurface  = NewEmptyPolyhedralSurface(b)
+		tin                = NewEmptyTin(b)
+		triangle           = NewEmptyTriangle(b)
+	)
+	cases := []struct {
+		geom Geometry
+		wkt  string
+		hex  string
+	}{
+		{&point, "POINT EMPTY", "0101000000000000000000f87f000000000000f87f"},
+		{
+			&pointZ, "SRID=4326;POINT Z EMPTY",
+			"01010000a0e6100000000000000000f87f000000000000f87f000000000000f87f",
+		},
+		{&lineString, "LINESTRING EMPTY", "010200000000000000"},
+		{&polygon, "POLYGON EMPTY", "010300000000000000"},
+		{&multiPoint, "MULTIPOINT EMPTY", "010400000000000000"},
+		{&multiLineString, "MULTILINESTRING EMPTY", "010500000000000000"},
+		{&multiPolygon, "MULTIPOLYGON EMPTY", "010600000000000000"},
+		{&geometryCollection, "GEOMETRYCOLLECTION EMPTY", "010700000000000000"},
+		{&circularString, "CIRCULARSTRING EMPTY", "010800000000000000"},
+		{&compoundCurve, "COMPOUNDCURVE EMPTY", "010900000000000000"},
+		{&curvePolygon, "CURVEPOLYGON EMPTY", "010a00000000000000"},
+		{&multiCurve, "MULTICURVE EMPTY", "010b00000000000000"},
+		{&multiSurface, "MULTISURFACE EMPTY", "010c00000000000000"},
+		{&polyhedralSurface, "POLYHEDRALSURFACE EMPTY", "010f00000000000000"},
+		{&tin, "TIN EMPTY", "011000000000000000"},
+		{&triangle, "TRIANGLE EMPTY", "011100000000000000"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.wkt, func(t *testing.T) {
+			if !c.geom.IsEmpty() {
+				t.Errorf("IsEmpty: expected %v, got %v\n", true, false)
+			}
+
+			if s := c.geom.String(); s != c.wkt {
+				t.Errorf("Expected %q, got %q\n", c.wkt, s)
+			}
+
+			data, err := c.geom.MarshalBinary()
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			if h := hex.EncodeToString(data); h != c.hex {
+				t.Errorf("Expected %v, got %v\n", c.hex, h)
+			}
+		})
+	}
+}
+
+func TestEmpty_PostGIS(t *testing.T) {
+	// Encodings, produced by PostGIS for nested empty geometries
+	cases := []struct {
+		wkt string
+		hex string
+	}{
+		{
+			"MULTIPOINT(EMPTY,1 2)",
+			"0104000000020000000101000000000000000000f87f000000000000f87f" +
+				"0101000000000000000000f03f0000000000000040",
+		},
+		{"MULTILINESTRING(EMPTY)", "010500000001000000010200000000000000"},
+		{"MULTIPOLYGON(EMPTY)", "010600000001000000010300000000000000"},
+		{"GEOMETRYCOLLECTION(GEOMETRYCOLLECTION EMPTY)", "010700000001000000010700000000000000"},
+		{
+			"SRID=4326;GEOMETRYCOLLECTION(POINT EMPTY,GEOMETRYCOLLECTION(POINT EMPTY))",
+			"0107000020e6100000020000000101000000000000000000f87f000000000000f87f" +
+				"0107000000010000000101000000000000000000f87f000000000000f87f",
+		},
+		{"MULTISURFACE(EMPTY,CURVEPOLYGON EMPTY)", "010c00000002000000010300000000000000010a00000000000000"},
+		{"COMPOUNDCURVE(EMPTY)", "010900000001000000010200000000000000"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.wkt, func(t *testing.T) {
+			g, err := ParseEWKT(c.wkt)
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			data, err := g.MarshalBinary()
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			if h := hex.EncodeToString(data); h != c.hex {
+				t.Errorf("Expected %v, got %v\n", c.hex, h)
+			}
+
+			var w Wrapper
+			if err = w.Scan(c.hex); err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			if s := w.Geometry.String(); s != c.wkt {
+				t.Errorf("Expected %q, got %q\n", c.wkt, s)
+			}
+		})
+	}
+}
+
+func TestIsEmpty(t *testing.T) {
+	cases := []struct {
+		wkt      string
+		expected bool
+	}{
+		{"POINT(1 2)", false},
+		{"POINT EMPTY", true},
+		{"LINESTRING(1 2,3 4)", false},
+		{"POLYGON(EMPTY)", true},
+		{"MULTIPOINT(EMPTY,EMPTY)", true},
+		{"MULTIPOINT(EMPTY,1 2)", false},
+		{"MULTILINESTRING(EMPTY,(1 2,3 4))", false},
+		{"MULTIPOLYGON(EMPTY,EMPTY)", true},
+		{"GEOMETRYCOLLECTION(POINT EMPTY,GEOMETRYCOLLECTION EMPTY)", true},
+		{"GEOMETRYCOLLECTION(POINT EMPTY,POINT(1 2))", false},
+		{"COMPOUNDCURVE(EMPTY,CIRCULARSTRING EMPTY)", true},
+		{"TIN(EMPTY)", true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.wkt, func(t *testing.T) {
+			g, err := ParseEWKT(c.wkt)
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			if e := g.IsEmpty(); e != c.expected {
+				t.Errorf("Expected %v, got %v\n", c.expected, e)
+			}
+		})
+	}
+}
+
+func TestPoint_IsEmpty_NaN(t *testing.T) {
+	// Point is empty only if all its coordinates are NaN
+	p := NewPoint(NewBase(NDR, false, false, false, 0), geo.NewPoint(math.NaN(), 1))
+	if p.IsEmpty() {
+		t.Errorf("Expected %v, got %v\n", false, true)
+	}
+
+	// NaN of any bit pattern is written as the one of PostGIS
+	p = NewPoint(NewBase(NDR, false, false, false, 0), geo.NewPoint(math.NaN(), math.NaN()))
+	data, err := p.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	expected := "0101000000000000000000f87f000000000000f87f"
+	if h := hex.EncodeToString(data); h != expected {
+		t.Errorf("Expected %v, got %v\n", expected, h)
+	}
+}
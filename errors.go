@@ -0,0 +1,189 @@
+package ewkb
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kcasctiv/go-ewkb/geo"
+)
+
+// Errors of binary decoding.
+// Decoding functions return them wrapped into DecodeError,
+// so they should be checked with errors.Is
+var (
+	// ErrTruncated is returned, when data ends before geometry is fully read
+	ErrTruncated = errors.New("ewkb: truncated data")
+	// ErrTrailingBytes is returned, when data continues after the end of geometry
+	ErrTrailingBytes = errors.New("ewkb: trailing bytes after geometry")
+	// ErrInvalidCount is returned, when count of points, rings
+	// or parts exceeds maximum of signed 32-bit integer.
+	// Count, which can not fit into remaining data,
+	// is reported as ErrTruncated at the first missing element
+	ErrInvalidCount = errors.New("ewkb: invalid count")
+	// ErrInvalidByteOrder is returned, when byte order is neither XDR nor NDR
+	ErrInvalidByteOrder = errors.New("ewkb: invalid byte order")
+	// ErrLimitExceeded is returned, when data exceeds
+	// one of the limits, specified by DecodeOptions
+	ErrLimitExceeded = errors.New("ewkb: decode limit exceeded")
+	// ErrUnknownType is returned for geometry type, not supported by package.
+	// It is also returned by validation and encoding of geometry,
+	// member of which is of Go type, not defined by package
+	ErrUnknownType = errors.New("ewkb: unknown geometry type")
+	// ErrTypeMismatch is returned, when geometry type differs from expected one.
+	// Details are available via TypeMismatchError
+	ErrTypeMismatch = errors.New("ewkb: geometry type mismatch")
+	// ErrInvalidTriangle is returned for triangle, which is not empty
+	// and does not consist of single ring of four closed points.
+	// It is also returned by encoding of such triangles
+	ErrInvalidTriangle = errors.New("ewkb: triangle must have four closed points")
+)
+
+// ErrDimensionMismatch is returned by validation of geometry,
+// when dimensions of point or member differ from dimensions
+// of geometry base. Details are available via DimensionMismatchError
+var ErrDimensionMismatch = errors.New("ewkb: dimensions mismatch")
+
+// ErrNilMember is returned by validation, encoding and linearization
+// of geometry, when one of its members is nil
+var ErrNilMember = errors.New("ewkb: nil member")
+
+// ErrSRIDMismatch is returned by validation and encoding of geometry,
+// when member has SRID, which differs from SRID of geometry.
+// Details are available via SRIDMismatchError
+var ErrSRIDMismatch = errors.New("ewkb: SRID mismatch")
+
+// unsupportedType returns ErrUnknownType for member
+// of geometry, which is of Go type, not defined by package.
+// Such member can not be encoded, even if it embeds geometry
+// of package, as its data is not accessible
+func unsupportedType(g Geometry) error {
+	return fmt.Errorf("%w: %T", ErrUnknownType, g)
+}
+
+// DecodeError presents error of binary decoding
+// with its location inside of data
+type DecodeError struct {
+	// Err is the cause of error, one of Err* values
+	// or TypeMismatchError
+	Err error
+	// Offset is byte offset from the beginning of data
+	Offset int
+	// Path is location inside of geometry,
+	// e.g. "polygon 2, ring 0, point 15".
+	// Empty for top level geometry
+	Path string
+}
+
+// Error implements error interface
+func (e *DecodeError) Error() string {
+	s := e.Err.Error() + " at offset " + strconv.Itoa(e.Offset)
+	if e.Path != "" {
+		s += " (" + e.Path + ")"
+	}
+
+	return s
+}
+
+// Unwrap returns the cause of error
+func (e *DecodeError) Unwrap() error { return e.Err }
+
+// TypeMismatchError presents error of unexpected geometry type
+type TypeMismatchError struct {
+	// Expected is expected type of geometry
+	Expected uint32
+	// Actual is type of geometry, found in data
+	Actual uint32
+}
+
+// Error implements error interface
+func (e *TypeMismatchError) Error() string {
+	return fmt.Sprintf("%v: expected %s, got %s",
+		ErrTypeMismatch, typeName(e.Expected), typeName(e.Actual))
+}
+
+// Is makes error match ErrTypeMismatch
+func (e *TypeMismatchError) Is(target error) bool { return target == ErrTypeMismatch }
+
+// DimensionMismatchError presents error of point or member,
+// dimensions of which differ from dimensions of geometry
+type DimensionMismatchError struct {
+	// Expected is layout of geometry base
+	Expected geo.Layout
+	// Actual is layout of point or member
+	Actual geo.Layout
+}
+
+// Error implements error interface
+func (e *DimensionMismatchError) Error() string {
+	return fmt.Sprintf("%v: expected %v, got %v", ErrDimensionMismatch, e.Expected, e.Actual)
+}
+
+// Is makes error match ErrDimensionMismatch
+func (e *DimensionMismatchError) Is(target error) bool { return target == ErrDimensionMismatch }
+
+// SRIDMismatchError presents error of member,
+// SRID of which differs from SRID of geometry
+type SRIDMismatchError struct {
+	// Expected is SRID of geometry, zero if it has no SRID
+	Expected int32
+	// Actual is SRID of member
+	Actual int32
+}
+
+// Error implements error interface
+func (e *SRIDMismatchError) Error() string {
+	return fmt.Sprintf("%v: expected %d, got %d", ErrSRIDMismatch, e.Expected, e.Actual)
+}
+
+// Is makes error match ErrSRIDMismatch
+func (e *SRIDMismatchError) Is(target error) bool { return target == ErrSRIDMismatch }
+
+// ValidationError presents error of geometry validation
+// with its location inside of geometry
+type ValidationError struct {
+	// Err is the cause of error, DimensionMismatchError,
+	// SRIDMismatchError or ErrInvalidTriangle
+	Err error
+	// Path is location inside of geometry,
+	// e.g. "polygon 2, ring 0, point 15".
+	// Empty for top level geometry
+	Path string
+}
+
+// Error implements error interface
+func (e *ValidationError) Error() string {
+	if e.Path == "" {
+		return e.Err.Error()
+	}
+
+	return e.Err.Error() + " (" + e.Path + ")"
+}
+
+// Unwrap returns the cause of error
+func (e *ValidationError) Unwrap() error { return e.Err }
+
+type pathElem struct {
+	name string
+	idx  int
+}
+
+func formatPath(path []pathElem) string {
+	elems := make([]string, len(path))
+	for idx, e := range path {
+		elems[idx] = e.name + " " + strconv.Itoa(e.idx)
+	}
+
+	return strings.Join(elems, ", ")
+}
+
+// typeName returns WKT name of geometry type,
+// or its code for unknown type
+func typeName(typ uint32) string {
+	if name := wktTypeName(typ); name != "" {
+		return name
+	}
+
+	return strconv.FormatUint(uint64(typ), 10)
+}
@@ -6,9 +6,11 @@ import (
 	"encoding"
 	"encoding/binary"
 	"encoding/hex"
-	"errors"
 	"fmt"
 	"math"
+	"strings"
+
+	"github.com/kcasctiv/go-ewkb/geo"
 )
 
 // Byte orders
@@ -24,6 +26,12 @@ const (
 	sridFlag uint32 = 0x20000000 // SRID flag
 )
 
+// ISO WKB type code offsets
+const (
+	isoZOffset uint32 = 1000 // Z dimension offset
+	isoMOffset uint32 = 2000 // M dimension offset
+)
+
 // Available types of geometry objects
 const (
 	PointType uint32 = 1 + iota
@@ -33,6 +41,18 @@ const (
 	MultiLineType
 	MultiPolygonType
 	CollectionType
+	CircularStringType
+	CompoundCurveType
+	CurvePolygonType
+	MultiCurveType
+	MultiSurfaceType
+)
+
+// Available types of polyhedral geometry objects
+const (
+	PolyhedralSurfaceType uint32 = 15 + iota
+	TinType
+	TriangleType
 )
 
 // Base presents interface of base of geometry
@@ -59,6 +79,17 @@ type Geometry interface {
 	driver.Valuer
 	encoding.BinaryUnmarshaler
 	encoding.BinaryMarshaler
+	// AppendBinary appends binary representation
+	// of geometry to dst and returns extended slice
+	AppendBinary(dst []byte) ([]byte, error)
+	// EncodedSize returns size of binary representation of geometry
+	EncodedSize() int
+	// IsEmpty checks if geometry has no points.
+	// Geometry, all members of which are empty, is empty too
+	IsEmpty() bool
+	// Validate checks that dimensions of all points
+	// and members of geometry match dimensions of its base
+	Validate() error
 }
 
 // NewBase returns new base of geometry
@@ -70,6 +101,112 @@ func NewBase(byteOrder byte, hasZ, hasM, hasSRID bool, srid int32) Base {
 	}
 }
 
+// EncodeOptions presents options of binary encoding
+type EncodeOptions struct {
+	// ISO enables ISO WKB dialect: dimensions are encoded
+	// with type code offsets (1000 for Z, 2000 for M, 3000 for ZM)
+	// instead of EWKB flags. ISO WKB can not contain SRID,
+	// so it is omitted
+	ISO bool
+}
+
+// Marshal returns binary representation of geometry,
+// encoded with specified options
+func Marshal(g Geometry, opts EncodeOptions) ([]byte, error) {
+	return AppendBinary(nil, g, opts)
+}
+
+// AppendBinary appends binary representation of geometry,
+// encoded with specified options, to dst and returns extended slice
+func AppendBinary(dst []byte, g Geometry, opts EncodeOptions) ([]byte, error) {
+	a, ok := g.(interface {
+		appendBinary(dst []byte, opts EncodeOptions) ([]byte, error)
+	})
+	if !ok {
+		return dst, ErrUnknownType
+	}
+
+	return a.appendBinary(dst, opts)
+}
+
+// DecodeOptions presents options of binary decoding
+type DecodeOptions struct {
+	// LegacyMulti enables reading of MultiPoint and MultiPolygon
+	// in legacy layout, written by previous versions of package,
+	// where member points and polygons are not preceded
+	// by byte order and type header
+	LegacyMulti bool
+	// MaxDepth limits nesting depth of geometry collections.
+	// If zero, DefaultMaxDepth is used
+	MaxDepth int
+	// MaxPoints limits total count of points. Zero means no limit
+	MaxPoints int
+	// MaxRings limits total count of polygon rings. Zero means no limit
+	MaxRings int
+	// MaxParts limits total count of members of multi geometries
+	// and collections. Zero means no limit
+	MaxParts int
+	// MaxInputSize limits size of data in bytes. Zero means no limit
+	MaxInputSize int
+}
+
+// DefaultMaxDepth is default limit of nesting depth of geometry collections
+const DefaultMaxDepth = 32
+
+func (o DecodeOptions) maxDepth() int {
+	if o.MaxDepth > 0 {
+		return o.MaxDepth
+	}
+
+	return DefaultMaxDepth
+}
+
+// Unmarshal decodes geometry of any supported type
+// from binary representation with specified options
+func Unmarshal(data []byte, opts DecodeOptions) (Geometry, error) {
+	return unmarshal(data, 0, opts)
+}
+
+// ValueFormat presents format of values,
+// returned by Value methods of geometry objects
+type ValueFormat int
+
+// Available formats of values
+const (
+	// ValueEWKT is EWKT text
+	ValueEWKT ValueFormat = iota + 1
+	// ValueEWKB is EWKB []byte
+	ValueEWKB
+	// ValueHexEWKB is EWKB, encoded to hex string
+	ValueHexEWKB
+)
+
+// DefaultValueFormat is format of values, returned by Value methods
+// of geometry objects and Wrapper without own format.
+// It must not be changed concurrently with calls of these methods
+var DefaultValueFormat = ValueEWKT
+
+// EWKTValue returns driver.Valuer, which presents geometry
+// as EWKT text regardless of DefaultValueFormat.
+// Nil geometry is presented as NULL
+func EWKTValue(g Geometry) driver.Valuer {
+	return &Wrapper{Geometry: g, ValueFormat: ValueEWKT}
+}
+
+// EWKBValue returns driver.Valuer, which presents geometry
+// as EWKB []byte regardless of DefaultValueFormat.
+// Nil geometry is presented as NULL
+func EWKBValue(g Geometry) driver.Valuer {
+	return &Wrapper{Geometry: g, ValueFormat: ValueEWKB}
+}
+
+// HexEWKBValue returns driver.Valuer, which presents geometry
+// as hex encoded EWKB regardless of DefaultValueFormat.
+// Nil geometry is presented as NULL
+func HexEWKBValue(g Geometry) driver.Valuer {
+	return &Wrapper{Geometry: g, ValueFormat: ValueHexEWKB}
+}
+
 // Wrapper prensents wrapper for geometry objects.
 // Can be used for reading from and writing to DB
 // all types of geometry, supported by package.
@@ -77,6 +214,13 @@ func NewBase(byteOrder byte, hasZ, hasM, hasSRID bool, srid int32) Base {
 // for nullable columns
 type Wrapper struct {
 	Geometry Geometry
+	// Options are used for decoding of geometry
+	// in Scan, UnmarshalBinary and UnmarshalText methods.
+	// For WKT MaxInputSize limits length of text
+	Options DecodeOptions
+	// ValueFormat is format of value, returned by Value method.
+	// If zero, DefaultValueFormat is used
+	ValueFormat ValueFormat
 }
 
 // Scan implements sql.Scanner interface
@@ -95,7 +239,11 @@ func (w *Wrapper) Value() (driver.Value, error) {
 		return nil, nil
 	}
 
-	return w.Geometry.Value()
+	if w.ValueFormat == 0 {
+		return w.Geometry.Value()
+	}
+
+	return geometryValue(w.Geometry, w.ValueFormat)
 }
 
 // UnmarshalBinary implements encoding.BinaryUnmarshaler interface
@@ -106,43 +254,7 @@ func (w *Wrapper) UnmarshalBinary(data []byte) error {
 	}
 
 	var err error
-	h, byteOrder, offset := readHeader(data)
-	switch h.Type() {
-	case PointType:
-		point := Point{header: h}
-		point.point, _, err = getReadPointFunc(h.wkbType)(data[offset:], byteOrder)
-		w.Geometry = &point
-	case LineType:
-		line := LineString{header: h}
-		line.mp, _, err = readMultiPoint(data[offset:], byteOrder, getReadPointFunc(h.wkbType))
-		w.Geometry = &line
-	case PolygonType:
-		poly := Polygon{header: h}
-		poly.poly, _, err = readPolygon(data[offset:], byteOrder, getReadPointFunc(h.wkbType))
-		w.Geometry = &poly
-	case MultiPointType:
-		mpoint := MultiPoint{header: h}
-		mpoint.mp, _, err = readMultiPoint(data[offset:], byteOrder, getReadPointFunc(h.wkbType))
-		w.Geometry = &mpoint
-	case MultiLineType:
-		mline := MultiLineString{header: h}
-		mline.ml, _, err = readMultiLine(data[offset:], byteOrder, getReadPointFunc(h.wkbType))
-		w.Geometry = &mline
-	case MultiPolygonType:
-		mpoly := MultiPolygon{header: h}
-		mpoly.mp, _, err = readMultiPolygon(data[offset:], byteOrder, getReadPointFunc(h.wkbType))
-		w.Geometry = &mpoly
-	case CollectionType:
-		gc := GeometryCollection{header: h}
-		gc.geoms, _, err = readCollection(data[offset:], byteOrder)
-		w.Geometry = &gc
-	default:
-		err = errors.New("not expected geometry type")
-	}
-	if err != nil {
-		w.Geometry = nil
-	}
-
+	w.Geometry, err = Unmarshal(data, w.Options)
 	return err
 }
 
@@ -155,6 +267,26 @@ func (w *Wrapper) MarshalBinary() ([]byte, error) {
 	return w.Geometry.MarshalBinary()
 }
 
+// AppendBinary implements encoding.BinaryAppender interface.
+// Nil geometry appends nothing
+func (w *Wrapper) AppendBinary(dst []byte) ([]byte, error) {
+	if w.Geometry == nil {
+		return dst, nil
+	}
+
+	return w.Geometry.AppendBinary(dst)
+}
+
+// EncodedSize returns size of binary representation
+// of geometry, or zero for nil geometry
+func (w *Wrapper) EncodedSize() int {
+	if w.Geometry == nil {
+		return 0
+	}
+
+	return w.Geometry.EncodedSize()
+}
+
 type header struct {
 	byteOrder byte
 	wkbType   uint32
@@ -179,23 +311,68 @@ func (h *header) HasSRID() bool { return (h.wkbType & sridFlag) == sridFlag }
 // SRID returns SRID, or zero, if there is no SRID
 func (h *header) SRID() int32 { return h.srid }
 
+// geometryValue returns value of geometry in specified format
+func geometryValue(g Geometry, format ValueFormat) (driver.Value, error) {
+	switch format {
+	case ValueEWKB:
+		return g.MarshalBinary()
+	case ValueHexEWKB:
+		data, err := g.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+
+		return hex.EncodeToString(data), nil
+	default:
+		return g.String(), nil
+	}
+}
+
+// scanGeometry decodes geometry from raw WKB/EWKB,
+// hex encoded WKB/EWKB or WKT/EWKT text
 func scanGeometry(src interface{}, unmarshaler encoding.BinaryUnmarshaler) error {
 	var data []byte
-	var err error
 	switch d := src.(type) {
 	case []byte:
-		if d[0] == 48 {
-			data, err = hex.DecodeString(string(d))
-		} else {
-			data = d
-		}
+		data = d
 	case string:
-		data, err = hex.DecodeString(d)
+		data = []byte(d)
 	default:
-		return errors.New("could not scan geometry")
+		return fmt.Errorf("could not scan geometry: unsupported source type %T", src)
+	}
+
+	// Binary representation starts with byte order,
+	// which is never printable character
+	if len(data) == 0 || data[0] == XDR || data[0] == NDR {
+		return unmarshaler.UnmarshalBinary(data)
+	}
+
+	text := strings.TrimSpace(string(data))
+	if len(text) >= 2 && text[0] == '0' && (text[1] == '0' || text[1] == '1') {
+		data, err := hex.DecodeString(text)
+		if err != nil {
+			return fmt.Errorf("could not scan geometry: %w", err)
+		}
+
+		return unmarshaler.UnmarshalBinary(data)
+	}
+
+	// Limits of options of Wrapper are checked while parsing,
+	// so that hostile text fails early
+	if w, ok := unmarshaler.(*Wrapper); ok {
+		if err := w.UnmarshalText([]byte(text)); err != nil {
+			return fmt.Errorf("could not scan geometry: %w", err)
+		}
+		return nil
 	}
+
+	g, err := ParseEWKT(text)
 	if err != nil {
-		return fmt.Errorf("could not scan geometry: %v", err)
+		return fmt.Errorf("could not scan geometry: %w", err)
+	}
+
+	if data, err = g.MarshalBinary(); err != nil {
+		return fmt.Errorf("could not scan geometry: %w", err)
 	}
 
 	return unmarshaler.UnmarshalBinary(data)
@@ -218,6 +395,65 @@ func getFlags(z, m, srid bool) uint32 {
 	return flags
 }
 
+// coordDims holds dimensions of coordinates being decoded
+// from text formats. If known is false, dimensions are taken
+// from the count of values of the first coordinate
+type coordDims struct {
+	known bool
+	z, m  bool
+}
+
+// infer sets dimensions by count of coordinate values
+func (d *coordDims) infer(n int) bool {
+	switch n {
+	case 2:
+		*d = coordDims{known: true}
+	case 3:
+		*d = coordDims{known: true, z: true}
+	case 4:
+		*d = coordDims{known: true, z: true, m: true}
+	default:
+		return false
+	}
+
+	return true
+}
+
+// count returns expected count of coordinate values
+func (d coordDims) count() int {
+	n := 2
+	if d.z {
+		n++
+	}
+
+	if d.m {
+		n++
+	}
+
+	return n
+}
+
+// point returns point, created from coordinate values,
+// the count of which must be equal to count()
+func (d coordDims) point(vals []float64) geo.Point {
+	switch {
+	case d.z && d.m:
+		return geo.NewPointZM(vals[0], vals[1], vals[2], vals[3])
+	case d.z:
+		return geo.NewPointZ(vals[0], vals[1], vals[2])
+	case d.m:
+		return geo.NewPointM(vals[0], vals[1], vals[2])
+	default:
+		return geo.NewPoint(vals[0], vals[1])
+	}
+}
+
+// emptyPoint returns point with NaN coordinates
+func (d coordDims) emptyPoint() geo.Point {
+	nan := math.Float64frombits(emptyCoord)
+	return d.point([]float64{nan, nan, nan, nan})
+}
+
 func getBinaryByteOrder(b byte) binary.ByteOrder {
 	if b == XDR {
 		return binary.BigEndian
@@ -1,6 +1,14 @@
 package ewkb
 
-import "testing"
+import (
+	"bytes"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/kcasctiv/go-ewkb/geo"
+)
 
 func TestNewBase(t *testing.T) {
 	cases := []struct {
@@ -41,3 +49,501 @@ func TestNewBase(t *testing.T) {
 		})
 	}
 }
+
+func TestWrapper_UnmarshalBinary_ISO(t *testing.T) {
+	cases := []string{
+		"POINT(1 2 3)",
+		"POINTM(1 2 3)",
+		"POINT(1 2 3 4)",
+		"LINESTRINGM(1 2 3,4 5 6)",
+		"POLYGON((1 2 3 4,5 6 7 8,9 10 11 12,1 2 3 4))",
+		"MULTIPOINT(1 2 3,4 5 6)",
+		"MULTILINESTRING((1 2 3 4,5 6 7 8))",
+		"MULTIPOLYGONM(((1 2 3,4 5 6,7 8 9,1 2 3)))",
+		"GEOMETRYCOLLECTION(POINT(1 2 3),LINESTRING(1 2 3,4 5 6))",
+	}
+
+	for _, c := range cases {
+		t.Run(c, func(t *testing.T) {
+			g, err := ParseEWKT(c)
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			b, err := Marshal(g, EncodeOptions{ISO: true})
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			var w Wrapper
+			if err := w.UnmarshalBinary(b); err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			if s := w.Geometry.String(); s != c {
+				t.Errorf("Expected %q, got %q\n", c, s)
+			}
+		})
+	}
+}
+
+func TestUnmarshal_Malformed(t *testing.T) {
+	cases := []struct {
+		name     string
+		data     []byte
+		expected error
+	}{
+		{"empty", []byte{}, ErrTruncated},
+		{"short header", []byte{1, 1, 0, 0}, ErrTruncated},
+		{"short SRID", []byte{1, 1, 0, 0, 32, 230, 16}, ErrTruncated},
+		{"invalid byte order", []byte{2, 1, 0, 0, 0, 0, 0, 0, 0}, ErrInvalidByteOrder},
+		{"missing count", []byte{1, 2, 0, 0, 0, 1, 0}, ErrTruncated},
+		{"huge line", []byte{1, 2, 0, 0, 0, 255, 255, 255, 255}, ErrInvalidCount},
+		{"huge polygon", []byte{1, 3, 0, 0, 0, 0, 0, 0, 128}, ErrInvalidCount},
+		{"truncated polygon", []byte{1, 3, 0, 0, 0, 255, 255, 255, 127}, ErrTruncated},
+		{"truncated multipoint", []byte{1, 4, 0, 0, 0, 2, 0, 0, 0, 1, 1, 0, 0, 0}, ErrTruncated},
+		{"truncated collection", []byte{1, 7, 0, 0, 0, 1, 0, 0, 0, 1, 7, 0, 0}, ErrTruncated},
+		{
+			"truncated collection member",
+			[]byte{
+				1, 7, 0, 0, 128, 1, 0, 0, 0, 1, 2, 0, 0, 128, 1, 0, 0, 0,
+				0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+			},
+			ErrTruncated,
+		},
+		{
+			"trailing bytes",
+			[]byte{1, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 28, 64, 0, 0, 0, 0, 0, 0, 32, 64, 0},
+			ErrTrailingBytes,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := Unmarshal(c.data, DecodeOptions{})
+			if !errors.Is(err, c.expected) {
+				t.Errorf("Expected %v, got %v\n", c.expected, err)
+			}
+		})
+	}
+}
+
+func TestUnmarshal_DecodeError(t *testing.T) {
+	g, err := ParseEWKT("MULTIPOLYGON(((0 0,1 1,1 0,0 0)),((0 0,1 1,1 0,0 0)))")
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	data, err := g.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	cases := []struct {
+		name     string
+		data     []byte
+		expected error
+		offset   int
+		path     string
+	}{
+		{"truncated ring", data[:139], ErrTruncated, 131, "polygon 1, ring 0, point 2"},
+		{"truncated polygon", data[:97], ErrTruncated, 95, "polygon 1, ring 0"},
+		{"truncated multipolygon", data[:12], ErrTruncated, 9, "polygon 0"},
+		{
+			"truncated point",
+			[]byte{1, 2, 0, 0, 128, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+			ErrTruncated,
+			9,
+			"point 0",
+		},
+		{"trailing bytes", append(data[:len(data):len(data)], 0), ErrTrailingBytes, len(data), ""},
+		{"unknown type", []byte{1, 99, 0, 0, 0}, ErrUnknownType, 0, ""},
+		{
+			"unknown member type",
+			[]byte{1, 7, 0, 0, 0, 1, 0, 0, 0, 1, 99, 0, 0, 0, 0, 0, 0, 0},
+			ErrUnknownType,
+			9,
+			"geometry 0",
+		},
+		{
+			"member type mismatch",
+			[]byte{
+				1, 4, 0, 0, 0, 1, 0, 0, 0, 1, 2, 0, 0, 0, 0, 0, 0,
+				0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+			},
+			ErrTypeMismatch,
+			9,
+			"point 0",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := Unmarshal(c.data, DecodeOptions{})
+			if !errors.Is(err, c.expected) {
+				t.Fatalf("Expected %v, got %v\n", c.expected, err)
+			}
+
+			var de *DecodeError
+			if !errors.As(err, &de) {
+				t.Fatalf("Expected *DecodeError, got %T\n", err)
+			}
+
+			if de.Offset != c.offset {
+				t.Errorf("Offset: expected %v, got %v\n", c.offset, de.Offset)
+			}
+
+			if de.Path != c.path {
+				t.Errorf("Path: expected %q, got %q\n", c.path, de.Path)
+			}
+		})
+	}
+}
+
+func TestWrapper_Scan_DecodeError(t *testing.T) {
+	var w Wrapper
+	err := w.Scan("0101000000000000000000")
+
+	var de *DecodeError
+	if !errors.As(err, &de) || !errors.Is(err, ErrTruncated) {
+		t.Fatalf("Expected %v, got %v\n", ErrTruncated, err)
+	}
+
+	if de.Offset != 5 {
+		t.Errorf("Offset: expected 5, got %v\n", de.Offset)
+	}
+}
+
+func TestWrapper_Scan_Empty(t *testing.T) {
+	var w Wrapper
+	if err := w.Scan([]byte{}); err == nil {
+		t.Error("Expected: error, got: no errors\n")
+	}
+
+	if err := w.Scan(""); err == nil {
+		t.Error("Expected: error, got: no errors\n")
+	}
+}
+
+func TestScan_Formats(t *testing.T) {
+	raw := []byte{
+		1, 1, 0, 0, 32, 230, 16, 0, 0,
+		0, 0, 0, 0, 0, 0, 240, 63, 0, 0, 0, 0, 0, 0, 0, 64,
+	}
+	cases := []struct {
+		name string
+		src  interface{}
+	}{
+		{"raw", raw},
+		{"hex string", "0101000020e6100000000000000000f03f0000000000000040"},
+		{"hex bytes", []byte("0101000020E6100000000000000000F03F0000000000000040")},
+		{"ewkt string", "SRID=4326;POINT(1 2)"},
+		{"ewkt bytes", []byte(" SRID=4326;point(1 2)\n")},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var p Point
+			if err := p.Scan(c.src); err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			if s := p.String(); s != "SRID=4326;POINT(1 2)" {
+				t.Errorf("Expected %q, got %q\n", "SRID=4326;POINT(1 2)", s)
+			}
+
+			var w Wrapper
+			if err := w.Scan(c.src); err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			if s := w.Geometry.String(); s != "SRID=4326;POINT(1 2)" {
+				t.Errorf("Expected %q, got %q\n", "SRID=4326;POINT(1 2)", s)
+			}
+		})
+	}
+
+	// WKT of other type
+	var p Point
+	if err := p.Scan("LINESTRING(1 2,3 4)"); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("Expected %v, got %v\n", ErrTypeMismatch, err)
+	}
+
+	// Invalid text
+	for _, src := range []interface{}{"0x0101", "POINT(1", []byte("01zz")} {
+		if err := p.Scan(src); err == nil {
+			t.Errorf("Expected: error, got: no errors (%v)\n", src)
+		}
+	}
+}
+
+func TestUnmarshal_Limits(t *testing.T) {
+	g, err := ParseEWKT(
+		"GEOMETRYCOLLECTION(POLYGON((0 0,1 1,1 0,0 0),(0 0,1 1,1 0,0 0)),MULTIPOINT(1 2,3 4))",
+	)
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	data, err := g.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	cases := []struct {
+		name  string
+		opts  DecodeOptions
+		valid bool
+	}{
+		{"no limits", DecodeOptions{}, true},
+		{"enough points", DecodeOptions{MaxPoints: 10}, true},
+		{"too many points", DecodeOptions{MaxPoints: 9}, false},
+		{"enough rings", DecodeOptions{MaxRings: 2}, true},
+		{"too many rings", DecodeOptions{MaxRings: 1}, false},
+		{"enough parts", DecodeOptions{MaxParts: 4}, true},
+		{"too many parts", DecodeOptions{MaxParts: 3}, false},
+		{"enough input size", DecodeOptions{MaxInputSize: len(data)}, true},
+		{"too large input", DecodeOptions{MaxInputSize: len(data) - 1}, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := Unmarshal(data, c.opts)
+			if err != nil && c.valid {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+			if err == nil && !c.valid {
+				t.Fatal("Expected: error, got: no errors\n")
+			}
+			if !c.valid && !errors.Is(err, ErrLimitExceeded) {
+				t.Errorf("Expected %v, got %v\n", ErrLimitExceeded, err)
+			}
+		})
+	}
+}
+
+func TestWrapper_Options(t *testing.T) {
+	w := Wrapper{Options: DecodeOptions{MaxPoints: 1}}
+	err := w.Scan("0102000000020000000000000000000000000000000000000000000000000000000000f03f000000000000f03f")
+	if !errors.Is(err, ErrLimitExceeded) {
+		t.Errorf("Expected %v, got %v\n", ErrLimitExceeded, err)
+	}
+}
+
+func TestWrapper_Value(t *testing.T) {
+	g, err := ParseEWKT("SRID=4326;POINT(1 2)")
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	data := []byte{
+		1, 1, 0, 0, 32, 230, 16, 0, 0,
+		0, 0, 0, 0, 0, 0, 240, 63, 0, 0, 0, 0, 0, 0, 0, 64,
+	}
+	hexData := "0101000020e6100000000000000000f03f0000000000000040"
+
+	cases := []struct {
+		name     string
+		valuer   driver.Valuer
+		expected driver.Value
+	}{
+		{"default", &Wrapper{Geometry: g}, "SRID=4326;POINT(1 2)"},
+		{"ewkt", &Wrapper{Geometry: g, ValueFormat: ValueEWKT}, "SRID=4326;POINT(1 2)"},
+		{"ewkb", &Wrapper{Geometry: g, ValueFormat: ValueEWKB}, data},
+		{"hex ewkb", &Wrapper{Geometry: g, ValueFormat: ValueHexEWKB}, hexData},
+		{"ewkt helper", EWKTValue(g), "SRID=4326;POINT(1 2)"},
+		{"ewkb helper", EWKBValue(g), data},
+		{"hex ewkb helper", HexEWKBValue(g), hexData},
+		{"nil", EWKBValue(nil), nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			v, err := c.valuer.Value()
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			if !reflect.DeepEqual(v, c.expected) {
+				t.Errorf("Expected %v, got %v\n", c.expected, v)
+			}
+		})
+	}
+}
+
+func TestDefaultValueFormat(t *testing.T) {
+	defer func(f ValueFormat) { DefaultValueFormat = f }(DefaultValueFormat)
+	DefaultValueFormat = ValueHexEWKB
+
+	g, err := ParseEWKT("POINT(1 2)")
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	expected := "0101000000000000000000f03f0000000000000040"
+	for _, valuer := range []driver.Valuer{g, &Wrapper{Geometry: g}} {
+		v, err := valuer.Value()
+		if err != nil {
+			t.Fatalf("Expected: no errors, got error: %v\n", err)
+		}
+
+		if v != expected {
+			t.Errorf("Expected %v, got %v\n", expected, v)
+		}
+	}
+
+	// Value of own format is scanned back
+	var w Wrapper
+	if err = w.Scan(expected); err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	if s := w.Geometry.String(); s != "POINT(1 2)" {
+		t.Errorf("Expected %q, got %q\n", "POINT(1 2)", s)
+	}
+}
+
+var appendBinaryGeoms = []string{
+	"SRID=4326;POINT(1 2)",
+	"LINESTRING(1 2 3,4 5 6)",
+	"POLYGON((0 0,1 1,1 0,0 0))",
+	"MULTIPOINT(1 2,3 4)",
+	"MULTILINESTRING((1 2,3 4),(5 6,7 8))",
+	"MULTIPOLYGON(((0 0,1 1,1 0,0 0)),((0 0,1 1,1 0,0 0)))",
+	"GEOMETRYCOLLECTION(POINT(1 2),GEOMETRYCOLLECTION(LINESTRING(1 2,3 4)))",
+}
+
+func TestAppendBinary(t *testing.T) {
+	for _, s := range appendBinaryGeoms {
+		t.Run(s, func(t *testing.T) {
+			g, err := ParseEWKT(s)
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			expected, err := g.MarshalBinary()
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			if size := g.EncodedSize(); size != len(expected) {
+				t.Errorf("EncodedSize: expected %v, got %v\n", len(expected), size)
+			}
+
+			prefix := []byte{1, 2, 3}
+			b, err := g.AppendBinary(prefix)
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			if !bytes.Equal(b[:3], prefix) || !bytes.Equal(b[3:], expected) {
+				t.Errorf("Expected %v, got %v\n", append(prefix, expected...), b)
+			}
+
+			buf := make([]byte, 0, g.EncodedSize())
+			allocs := testing.AllocsPerRun(10, func() {
+				buf, _ = g.AppendBinary(buf[:0])
+			})
+			if allocs != 0 {
+				t.Errorf("Allocs: expected 0, got %v\n", allocs)
+			}
+		})
+	}
+}
+
+func TestAppendBinary_Amortized(t *testing.T) {
+	g, err := ParseEWKT("POINT(1 2)")
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	var buf []byte
+	reallocs := 0
+	for idx := 0; idx < 1000; idx++ {
+		c := cap(buf)
+		if buf, err = g.AppendBinary(buf); err != nil {
+			t.Fatalf("Expected: no errors, got error: %v\n", err)
+		}
+		if cap(buf) != c {
+			reallocs++
+		}
+	}
+
+	if len(buf) != 1000*g.EncodedSize() {
+		t.Errorf("Expected %v, got %v\n", 1000*g.EncodedSize(), len(buf))
+	}
+
+	if reallocs > 50 {
+		t.Errorf("Expected at most %v reallocations, got %v\n", 50, reallocs)
+	}
+}
+
+func TestWrapper_AppendBinary(t *testing.T) {
+	var w Wrapper
+	b, err := w.AppendBinary([]byte{1})
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	if !bytes.Equal(b, []byte{1}) || w.EncodedSize() != 0 {
+		t.Errorf("Expected %v, got %v\n", []byte{1}, b)
+	}
+}
+
+func BenchmarkAppendBinary(b *testing.B) {
+	for _, s := range appendBinaryGeoms {
+		g, err := ParseEWKT(s)
+		if err != nil {
+			b.Fatalf("Expected: no errors, got error: %v\n", err)
+		}
+
+		b.Run(s, func(b *testing.B) {
+			buf := make([]byte, 0, g.EncodedSize())
+			b.ReportAllocs()
+			for idx := 0; idx < b.N; idx++ {
+				buf, _ = g.AppendBinary(buf[:0])
+			}
+		})
+	}
+}
+
+func BenchmarkMarshalBinary(b *testing.B) {
+	for _, s := range appendBinaryGeoms {
+		g, err := ParseEWKT(s)
+		if err != nil {
+			b.Fatalf("Expected: no errors, got error: %v\n", err)
+		}
+
+		b.Run(s, func(b *testing.B) {
+			b.ReportAllocs()
+			for idx := 0; idx < b.N; idx++ {
+				_, _ = g.MarshalBinary()
+			}
+		})
+	}
+}
+
+func BenchmarkUnmarshal_LargePolygon(b *testing.B) {
+	flat := make([]float64, 0, 200000)
+	for idx := 0; idx < 100000; idx++ {
+		flat = append(flat, float64(idx), float64(-idx))
+	}
+	flat = append(flat[:len(flat)-2], 0, 0)
+
+	poly := NewPolygon(
+		NewBase(NDR, false, false, true, 4326),
+		geo.NewFlatPolygon(flat, []int{100000}, false, false),
+	)
+	data, err := poly.MarshalBinary()
+	if err != nil {
+		b.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(data)))
+	for idx := 0; idx < b.N; idx++ {
+		if _, err = Unmarshal(data, DecodeOptions{}); err != nil {
+			b.Fatalf("Expected: no errors, got error: %v\n", err)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package ewkb
+
+import (
+	"math"
+
+	"github.com/kcasctiv/go-ewkb/geo"
+)
+
+// Force2D returns geometry of the same type without Z and M
+// dimensions, as PostGIS ST_Force2D does. Members of collections
+// are coerced recursively. Nil geometry is returned as is.
+// Geometry of Wrapper is coerced with methods of Wrapper
+func Force2D(g Geometry) Geometry {
+	return forcer{}.geometry(g)
+}
+
+// Force3DZ returns geometry of the same type with Z and without
+// M dimension, as PostGIS ST_Force3DZ does. Points without Z
+// get value z, Z of empty points is NaN
+func Force3DZ(g Geometry, z float64) Geometry {
+	return forcer{hasZ: true, z: z}.geometry(g)
+}
+
+// Force3DM returns geometry of the same type with M and without
+// Z dimension, as PostGIS ST_Force3DM does. Points without M
+// get value m, M of empty points is NaN
+func Force3DM(g Geometry, m float64) Geometry {
+	return forcer{hasM: true, m: m}.geometry(g)
+}
+
+// Force4D returns geometry of the same type with Z and M
+// dimensions, as PostGIS ST_Force4D does. Points without Z or M
+// get values z and m, Z and M of empty points are NaN
+func Force4D(g Geometry, z, m float64) Geometry {
+	return forcer{hasZ: true, hasM: true, z: z, m: m}.geometry(g)
+}
+
+// Force2D returns copy of wrapper, geometry of which
+// is coerced with Force2D. Nil geometry stays nil
+func (w *Wrapper) Force2D() *Wrapper {
+	c := *w
+	c.Geometry = Force2D(w.Geometry)
+	return &c
+}
+
+// Force3DZ returns copy of wrapper, geometry of which
+// is coerced with Force3DZ. Nil geometry stays nil
+func (w *Wrapper) Force3DZ(z float64) *Wrapper {
+	c := *w
+	c.Geometry = Force3DZ(w.Geometry, z)
+	return &c
+}
+
+// Force3DM returns copy of wrapper, geometry of which
+// is coerced with Force3DM. Nil geometry stays nil
+func (w *Wrapper) Force3DM(m float64) *Wrapper {
+	c := *w
+	c.Geometry = Force3DM(w.Geometry, m)
+	return &c
+}
+
+// Force4D returns copy of wrapper, geometry of which
+// is coerced with Force4D. Nil geometry stays nil
+func (w *Wrapper) Force4D(z, m float64) *Wrapper {
+	c := *w
+	c.Geometry = Force4D(w.Geometry, z, m)
+	return &c
+}
+
+// forcer holds dimensions of coerced geometry
+// and values of added dimensions
+type forcer struct {
+	hasZ, hasM bool
+	z, m       float64
+}
+
+func (f forcer) geometry(geom Geometry) Geometry {
+	if geom == nil {
+		return nil
+	}
+
+	b := NewBase(geom.ByteOrder(), f.hasZ, f.hasM, geom.HasSRID(), geom.SRID())
+	switch g := geom.(type) {
+	case *Point:
+		dims := coordDims{known: true, z: f.hasZ, m: f.hasM}
+		p := NewPoint(b, dims.point(f.appendPoint(nil, g.point, g)))
+		return &p
+	case *LineString:
+		l := NewLineString(b, f.multiPoint(g.mp, g))
+		return &l
+	case *CircularString:
+		c := NewCircularString(b, f.multiPoint(g.mp, g))
+		return &c
+	case *MultiPoint:
+		p := NewMultiPoint(b, f.multiPoint(g.mp, g))
+		return &p
+	case *Polygon:
+		p := NewPolygon(b, f.polygon(g.poly, g))
+		return &p
+	case *Triangle:
+		t := NewTriangle(b, f.polygon(g.poly, g))
+		return &t
+	case *MultiLineString:
+		lines := make([]geo.MultiPoint, g.Len())
+		for idx := range lines {
+			lines[idx] = f.multiPoint(g.Line(idx), g)
+		}
+
+		l := NewMultiLineString(b, geo.NewMultiLine(lines))
+		return &l
+	case *MultiPolygon:
+		p := NewMultiPolygon(b, f.multiPolygon(g.mp, g))
+		return &p
+	case *PolyhedralSurface:
+		p := NewPolyhedralSurface(b, f.multiPolygon(g.mp, g))
+		return &p
+	case *Tin:
+		t := NewTin(b, f.multiPolygon(g.mp, g))
+		return &t
+	case *GeometryCollection:
+		c := NewGeometryCollection(b, f.members(g.geoms))
+		return &c
+	case *CompoundCurve:
+		c := NewCompoundCurve(b, f.members(g.curves))
+		return &c
+	case *CurvePolygon:
+		p := NewCurvePolygon(b, f.members(g.rings))
+		return &p
+	case *MultiCurve:
+		c := NewMultiCurve(b, f.members(g.curves))
+		return &c
+	case *MultiSurface:
+		s := NewMultiSurface(b, f.members(g.surfaces))
+		return &s
+	default:
+		return geom
+	}
+}
+
+// appendPoint appends coordinates of point of geometry base
+// to dst. Dimensions, which base or point does not have,
+// are filled with values of forcer, or with NaN for empty point
+func (f forcer) appendPoint(dst []float64, p geo.Point, base Base) []float64 {
+	empty := isEmptyPoint(p, base.HasZ(), base.HasM())
+	layout := p.Layout()
+	dst = append(dst, p.X(), p.Y())
+	if f.hasZ {
+		switch {
+		case base.HasZ() && layout.HasZ():
+			dst = append(dst, p.Z())
+		case empty:
+			dst = append(dst, math.Float64frombits(emptyCoord))
+		default:
+			dst = append(dst, f.z)
+		}
+	}
+
+	if f.hasM {
+		switch {
+		case base.HasM() && layout.HasM():
+			dst = append(dst, p.M())
+		case empty:
+			dst = append(dst, math.Float64frombits(emptyCoord))
+		default:
+			dst = append(dst, f.m)
+		}
+	}
+
+	return dst
+}
+
+func (f forcer) multiPoint(mp geo.MultiPoint, base Base) geo.Sequence {
+	flat := make([]float64, 0, mp.Len()*pointSize(f.hasZ, f.hasM)/8)
+	for idx := 0; idx < mp.Len(); idx++ {
+		flat = f.appendPoint(flat, mp.Point(idx), base)
+	}
+
+	return geo.NewSequence(flat, f.hasZ, f.hasM)
+}
+
+func (f forcer) polygon(p geo.Polygon, base Base) geo.Polygon {
+	var flat []float64
+	ends := make([]int, p.Len())
+	stride := pointSize(f.hasZ, f.hasM) / 8
+	for idx := range ends {
+		ring := p.Ring(idx)
+		for pidx := 0; pidx < ring.Len(); pidx++ {
+			flat = f.appendPoint(flat, ring.Point(pidx), base)
+		}
+		ends[idx] = len(flat) / stride
+	}
+
+	return geo.NewFlatPolygon(flat, ends, f.hasZ, f.hasM)
+}
+
+func (f forcer) multiPolygon(mp geo.MultiPolygon, base Base) geo.MultiPolygon {
+	pols := make([]geo.Polygon, mp.Len())
+	for idx := range pols {
+		pols[idx] = f.polygon(mp.Polygon(idx), base)
+	}
+
+	return geo.NewMultiPolygon(pols)
+}
+
+func (f forcer) members(geoms []Geometry) []Geometry {
+	if geoms == nil {
+		return nil
+	}
+
+	members := make([]Geometry, len(geoms))
+	for idx, geom := range geoms {
+		members[idx] = f.geometry(geom)
+	}
+
+	return members
+}
@@ -0,0 +1,136 @@
+package ewkb
+
+import (
+	"math"
+	"testing"
+)
+
+func TestForce(t *testing.T) {
+	cases := []struct {
+		wkt   string
+		force func(Geometry) Geometry
+		exp   string
+	}{
+		{"SRID=4326;POINT(1 2 3 4)", Force2D, "SRID=4326;POINT(1 2)"},
+		{"POINT(1 2)", force3DZ, "POINT(1 2 7)"},
+		{"POINTM(1 2 3)", force3DZ, "POINT(1 2 7)"},
+		{"POINT(1 2 3)", force3DM, "POINTM(1 2 8)"},
+		{"POINTM(1 2 3)", force4D, "POINT(1 2 7 3)"},
+		{"POINT EMPTY", force4D, "POINT ZM EMPTY"},
+		{"LINESTRING(0 0 1,1 1 2)", force3DM, "LINESTRINGM(0 0 8,1 1 8)"},
+		{"CIRCULARSTRING(0 0,1 1,2 0)", force3DZ, "CIRCULARSTRING(0 0 7,1 1 7,2 0 7)"},
+		{"MULTIPOINT(1 2,EMPTY)", force3DZ, "MULTIPOINT(1 2 7,EMPTY)"},
+		{
+			"POLYGON((0 0,0 1,1 1,0 0),(0 0,0 1,1 1,0 0))", force3DM,
+			"POLYGONM((0 0 8,0 1 8,1 1 8,0 0 8),(0 0 8,0 1 8,1 1 8,0 0 8))",
+		},
+		{"TRIANGLE((0 0 1,0 1 1,1 1 1,0 0 1))", Force2D, "TRIANGLE((0 0,0 1,1 1,0 0))"},
+		{"MULTILINESTRING((0 0,1 1),(2 2,3 3))", force3DZ, "MULTILINESTRING((0 0 7,1 1 7),(2 2 7,3 3 7))"},
+		{"MULTIPOLYGON(((0 0,0 1,1 1,0 0)),EMPTY)", force3DZ, "MULTIPOLYGON(((0 0 7,0 1 7,1 1 7,0 0 7)),EMPTY)"},
+		{"TIN(((0 0 1 2,0 1 1 2,1 1 1 2,0 0 1 2)))", Force2D, "TIN(((0 0,0 1,1 1,0 0)))"},
+		{"POLYHEDRALSURFACE(((0 0,0 1,1 1,0 0)))", force3DM, "POLYHEDRALSURFACEM(((0 0 8,0 1 8,1 1 8,0 0 8)))"},
+		{
+			"SRID=3857;GEOMETRYCOLLECTION(POINT(1 2),GEOMETRYCOLLECTION(LINESTRING(0 0,1 1)),POINT EMPTY)", force3DZ,
+			"SRID=3857;GEOMETRYCOLLECTION(POINT(1 2 7),GEOMETRYCOLLECTION(LINESTRING(0 0 7,1 1 7)),POINT Z EMPTY)",
+		},
+		{"GEOMETRYCOLLECTION EMPTY", force4D, "GEOMETRYCOLLECTION ZM EMPTY"},
+		{
+			"COMPOUNDCURVE(CIRCULARSTRING(0 0 1,1 1 1,2 0 1),(2 0 1,3 0 1))", Force2D,
+			"COMPOUNDCURVE(CIRCULARSTRING(0 0,1 1,2 0),(2 0,3 0))",
+		},
+		{
+			"CURVEPOLYGON(CIRCULARSTRING(0 0,1 1,2 0,1 -1,0 0))", force3DM,
+			"CURVEPOLYGONM(CIRCULARSTRINGM(0 0 8,1 1 8,2 0 8,1 -1 8,0 0 8))",
+		},
+		{"MULTICURVE((0 0,1 1))", force3DZ, "MULTICURVE((0 0 7,1 1 7))"},
+		{"MULTISURFACE(((0 0,0 1,1 1,0 0)))", force3DZ, "MULTISURFACE(((0 0 7,0 1 7,1 1 7,0 0 7)))"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.wkt, func(t *testing.T) {
+			g, err := ParseEWKT(c.wkt)
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			fg := c.force(g)
+			if fg.Type() != g.Type() {
+				t.Errorf("Expected %v, got %v\n", g.Type(), fg.Type())
+			}
+
+			if s := fg.String(); s != c.exp {
+				t.Errorf("Expected %q, got %q\n", c.exp, s)
+			}
+
+			if err = fg.Validate(); err != nil {
+				t.Errorf("Expected: no errors, got error: %v\n", err)
+			}
+
+			if _, err = fg.MarshalBinary(); err != nil {
+				t.Errorf("Expected: no errors, got error: %v\n", err)
+			}
+
+			if s := g.String(); s != c.wkt {
+				t.Errorf("Source geometry changed: expected %q, got %q\n", c.wkt, s)
+			}
+		})
+	}
+}
+
+func TestForce_EmptyPoint(t *testing.T) {
+	g, err := ParseEWKT("POINT EMPTY")
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	p := Force4D(g, 7, 8).(*Point)
+	if !p.IsEmpty() || !math.IsNaN(p.Z()) || !math.IsNaN(p.M()) {
+		t.Errorf("Expected %v, got %v\n", "empty point", p.String())
+	}
+}
+
+func TestForce_Nil(t *testing.T) {
+	var w Wrapper
+	if f := w.Force2D(); f.Geometry != nil {
+		t.Errorf("Expected %v, got %v\n", nil, f.Geometry)
+	}
+}
+
+func TestForce_Wrapper(t *testing.T) {
+	g, err := ParseEWKT("SRID=4326;LINESTRING M(1 2 3,4 5 6)")
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	w := &Wrapper{Geometry: g, ValueFormat: ValueHexEWKB}
+	cases := []struct {
+		name     string
+		forced   *Wrapper
+		expected string
+	}{
+		{"2D", w.Force2D(), "SRID=4326;LINESTRING(1 2,4 5)"},
+		{"3DZ", w.Force3DZ(7), "SRID=4326;LINESTRING(1 2 7,4 5 7)"},
+		{"3DM", w.Force3DM(8), "SRID=4326;LINESTRINGM(1 2 3,4 5 6)"},
+		{"4D", w.Force4D(7, 8), "SRID=4326;LINESTRING(1 2 7 3,4 5 7 6)"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if s := c.forced.Geometry.String(); s != c.expected {
+				t.Errorf("Expected %q, got %q\n", c.expected, s)
+			}
+
+			if c.forced.ValueFormat != ValueHexEWKB {
+				t.Errorf("Expected %v, got %v\n", ValueHexEWKB, c.forced.ValueFormat)
+			}
+		})
+	}
+
+	if s := w.Geometry.String(); s != "SRID=4326;LINESTRINGM(1 2 3,4 5 6)" {
+		t.Errorf("Expected %q, got %q\n", "SRID=4326;LINESTRINGM(1 2 3,4 5 6)", s)
+	}
+}
+
+func force3DZ(g Geometry) Geometry { return Force3DZ(g, 7) }
+func force3DM(g Geometry) Geometry { return Force3DM(g, 8) }
+func force4D(g Geometry) Geometry  { return Force4D(g, 7, 8) }
@@ -0,0 +1,72 @@
+package ewkb
+
+import (
+	"bytes"
+	"encoding"
+	"testing"
+)
+
+// FuzzUnmarshal checks, that decoding never panics on malformed
+// input, that decoded geometry can be encoded back
+// and that transcoded data can be decoded.
+// Seed corpus is located in testdata/fuzz/FuzzUnmarshal
+func FuzzUnmarshal(f *testing.F) {
+	f.Fuzz(func(t *testing.T, data []byte) {
+		for _, opts := range []DecodeOptions{{}, {LegacyMulti: true}} {
+			g, err := Unmarshal(data, opts)
+			v, verr := NewView(data, opts)
+			if (err == nil) != (verr == nil) {
+				t.Fatalf("Expected equal errors, got %v and %v\n", err, verr)
+			}
+			if err != nil {
+				continue
+			}
+
+			if _, err := v.Geometry(); err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+			_ = v.Bounds()
+
+			_ = g.String()
+			if _, err := g.MarshalBinary(); err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+		}
+
+		if xdr, err := Transcode(data, XDR); err == nil {
+			if _, err = Unmarshal(xdr, DecodeOptions{}); err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+		}
+
+		geoms := []encoding.BinaryUnmarshaler{
+			&Point{}, &LineString{}, &Polygon{}, &MultiPoint{},
+			&MultiLineString{}, &MultiPolygon{}, &GeometryCollection{},
+			&Wrapper{},
+		}
+		for _, g := range geoms {
+			_ = g.UnmarshalBinary(data)
+		}
+
+		dec := NewDecoder(bytes.NewReader(data))
+		for {
+			if _, err := dec.Decode(); err != nil {
+				break
+			}
+		}
+	})
+}
+
+// FuzzScan checks, that scanning never panics on malformed input
+func FuzzScan(f *testing.F) {
+	f.Add("01010000000000000000001c400000000000002040")
+	f.Add("0102000020e61000000200000000000000000000000000000000000000000000000000f03f000000000000f03f")
+	f.Add("0107000000010000000107000000000000000")
+	f.Add("")
+
+	f.Fuzz(func(t *testing.T, src string) {
+		var w Wrapper
+		_ = w.Scan(src)
+		_ = w.Scan([]byte(src))
+	})
+}
@@ -0,0 +1,114 @@
+package geo
+
+// Sequence presents multi point, coordinates of which
+// are stored in single slice. Every point takes stride
+// values: X, Y, then Z and M, if sequence has them.
+// Sequence does not allocate memory for access to points
+type Sequence struct {
+	flat       []float64
+	hasZ, hasM bool
+}
+
+// NewSequence returns new sequence of points with specified
+// dimensions, backed by flat. Length of flat must be multiple
+// of stride. Flat is not copied and must not be modified later
+func NewSequence(flat []float64, hasZ, hasM bool) Sequence {
+	return Sequence{flat: flat, hasZ: hasZ, hasM: hasM}
+}
+
+// Point returns point with specified index
+func (s Sequence) Point(idx int) Point {
+	stride := s.Stride()
+	start := idx * stride
+	switch {
+	case s.hasZ && s.hasM:
+		return (*xyzm)(s.flat[start : start+stride])
+	case s.hasZ:
+		return (*xyz)(s.flat[start : start+stride])
+	case s.hasM:
+		return (*xym)(s.flat[start : start+stride])
+	default:
+		return (*xy)(s.flat[start : start+stride])
+	}
+}
+
+// Len returns count of points
+func (s Sequence) Len() int { return len(s.flat) / s.Stride() }
+
+// HasZ checks if points have Z dimension
+func (s Sequence) HasZ() bool { return s.hasZ }
+
+// HasM checks if points have M dimension
+func (s Sequence) HasM() bool { return s.hasM }
+
+// Layout returns dimensions of points
+func (s Sequence) Layout() Layout { return NewLayout(s.hasZ, s.hasM) }
+
+// Stride returns count of values of single point
+func (s Sequence) Stride() int { return s.Layout().Stride() }
+
+// Flat returns coordinates of all points.
+// Returned slice must not be modified
+func (s Sequence) Flat() []float64 { return s.flat }
+
+type xy [2]float64
+
+func (p *xy) X() float64     { return p[0] }
+func (p *xy) Y() float64     { return p[1] }
+func (p *xy) Z() float64     { return 0 }
+func (p *xy) M() float64     { return 0 }
+func (p *xy) Layout() Layout { return XY }
+
+type xyz [3]float64
+
+func (p *xyz) X() float64     { return p[0] }
+func (p *xyz) Y() float64     { return p[1] }
+func (p *xyz) Z() float64     { return p[2] }
+func (p *xyz) M() float64     { return 0 }
+func (p *xyz) Layout() Layout { return XYZ }
+
+type xym [3]float64
+
+func (p *xym) X() float64     { return p[0] }
+func (p *xym) Y() float64     { return p[1] }
+func (p *xym) Z() float64     { return 0 }
+func (p *xym) M() float64     { return p[2] }
+func (p *xym) Layout() Layout { return XYM }
+
+type xyzm [4]float64
+
+func (p *xyzm) X() float64     { return p[0] }
+func (p *xyzm) Y() float64     { return p[1] }
+func (p *xyzm) Z() float64     { return p[2] }
+func (p *xyzm) M() float64     { return p[3] }
+func (p *xyzm) Layout() Layout { return XYZM }
+
+// FlatPolygon presents polygon, coordinates of all rings
+// of which are stored in single slice
+type FlatPolygon struct {
+	rings []Sequence
+}
+
+// NewFlatPolygon returns new polygon with specified dimensions,
+// backed by flat. Ends holds indexes of points, following
+// the last point of every ring, so ring idx consists of points
+// from ends[idx-1] (or 0) up to ends[idx].
+// Flat is not copied and must not be modified later
+func NewFlatPolygon(flat []float64, ends []int, hasZ, hasM bool) FlatPolygon {
+	seq := NewSequence(flat, hasZ, hasM)
+	stride := seq.Stride()
+	rings := make([]Sequence, len(ends))
+	start := 0
+	for idx, end := range ends {
+		rings[idx] = NewSequence(flat[start*stride:end*stride:end*stride], hasZ, hasM)
+		start = end
+	}
+
+	return FlatPolygon{rings: rings}
+}
+
+// Ring returns ring with specified index
+func (p FlatPolygon) Ring(idx int) MultiPoint { return &p.rings[idx] }
+
+// Len returns count of rings
+func (p FlatPolygon) Len() int { return len(p.rings) }
@@ -0,0 +1,110 @@
+package geo
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSequence(t *testing.T) {
+	cases := []struct {
+		name       string
+		flat       []float64
+		hasZ, hasM bool
+		expected   MultiPoint
+	}{
+		{
+			"2 dimensions",
+			[]float64{1, 2, 3, 4},
+			false, false,
+			NewMultiPoint([]Point{NewPoint(1, 2), NewPoint(3, 4)}),
+		},
+		{
+			"Z dimension",
+			[]float64{1, 2, 3, 4, 5, 6},
+			true, false,
+			NewMultiPoint([]Point{NewPointZ(1, 2, 3), NewPointZ(4, 5, 6)}),
+		},
+		{
+			"M dimension",
+			[]float64{1, 2, 3, 4, 5, 6},
+			false, true,
+			NewMultiPoint([]Point{NewPointM(1, 2, 3), NewPointM(4, 5, 6)}),
+		},
+		{
+			"Z and M dimensions",
+			[]float64{1, 2, 3, 4, 5, 6, 7, 8},
+			true, true,
+			NewMultiPoint([]Point{NewPointZM(1, 2, 3, 4), NewPointZM(5, 6, 7, 8)}),
+		},
+		{"empty", nil, false, false, NewMultiPoint(nil)},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			s := NewSequence(c.flat, c.hasZ, c.hasM)
+			checkMultiPoint(t, s, c.expected, "")
+
+			if stride := s.Stride(); stride*s.Len() != len(c.flat) {
+				t.Errorf("Stride: expected %v, got %v\n", len(c.flat)/s.Len(), stride)
+			}
+		})
+	}
+}
+
+func TestFlatPolygon(t *testing.T) {
+	p := NewFlatPolygon(
+		[]float64{0, 0, 1, 1, 1, 0, 0, 0, 5, 6, 7, 8, 9, 10},
+		[]int{4, 4, 7},
+		false, false,
+	)
+
+	rings := []MultiPoint{
+		NewMultiPoint([]Point{NewPoint(0, 0), NewPoint(1, 1), NewPoint(1, 0), NewPoint(0, 0)}),
+		NewMultiPoint(nil),
+		NewMultiPoint([]Point{NewPoint(5, 6), NewPoint(7, 8), NewPoint(9, 10)}),
+	}
+
+	if pl := p.Len(); pl != len(rings) {
+		t.Fatalf("Len: extected %v, got %v\n", len(rings), pl)
+	}
+
+	for idx := 0; idx < p.Len(); idx++ {
+		checkMultiPoint(t, p.Ring(idx), rings[idx], fmt.Sprintf("Ring %d: ", idx))
+	}
+}
+
+const benchmarkPoints = 100000
+
+func BenchmarkNewMultiPoint(b *testing.B) {
+	b.ReportAllocs()
+	for idx := 0; idx < b.N; idx++ {
+		points := make([]Point, benchmarkPoints)
+		for p := range points {
+			points[p] = NewPoint(float64(p), float64(p))
+		}
+
+		sumCoords(NewMultiPoint(points))
+	}
+}
+
+func BenchmarkNewSequence(b *testing.B) {
+	b.ReportAllocs()
+	for idx := 0; idx < b.N; idx++ {
+		flat := make([]float64, 0, benchmarkPoints*2)
+		for p := 0; p < benchmarkPoints; p++ {
+			flat = append(flat, float64(p), float64(p))
+		}
+
+		sumCoords(NewSequence(flat, false, false))
+	}
+}
+
+func sumCoords(mp MultiPoint) float64 {
+	var sum float64
+	for idx := 0; idx < mp.Len(); idx++ {
+		p := mp.Point(idx)
+		sum += p.X() + p.Y()
+	}
+
+	return sum
+}
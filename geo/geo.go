@@ -3,6 +3,8 @@
 // and their creation methods
 package geo
 
+import "strconv"
+
 // Point presents interface of point
 type Point interface {
 	// X returns value of X dimension
@@ -13,14 +15,82 @@ type Point interface {
 	Z() float64
 	// M returns value of X dimension
 	M() float64
+	// Layout returns dimensions of point
+	Layout() Layout
+}
+
+// Layout presents set of dimensions of point
+type Layout uint8
+
+// Available layouts
+const (
+	// XY is layout of 2 dimensions point
+	XY Layout = iota
+	// XYZ is layout of 3 dimensions point with Z dimension
+	XYZ
+	// XYM is layout of 3 dimensions point with M dimension
+	XYM
+	// XYZM is layout of 4 dimensions point
+	XYZM
+)
+
+// NewLayout returns layout with specified dimensions
+func NewLayout(hasZ, hasM bool) Layout {
+	var l Layout
+	if hasZ {
+		l |= XYZ
+	}
+
+	if hasM {
+		l |= XYM
+	}
+
+	return l
+}
+
+// HasZ checks if layout has Z dimension
+func (l Layout) HasZ() bool { return l&XYZ != 0 }
+
+// HasM checks if layout has M dimension
+func (l Layout) HasM() bool { return l&XYM != 0 }
+
+// Stride returns count of values of single point
+func (l Layout) Stride() int {
+	stride := 2
+	if l.HasZ() {
+		stride++
+	}
+
+	if l.HasM() {
+		stride++
+	}
+
+	return stride
+}
+
+// String returns name of layout, e.g. "XYZ"
+func (l Layout) String() string {
+	switch l {
+	case XY:
+		return "XY"
+	case XYZ:
+		return "XYZ"
+	case XYM:
+		return "XYM"
+	case XYZM:
+		return "XYZM"
+	default:
+		return "Layout(" + strconv.Itoa(int(l)) + ")"
+	}
 }
 
 type point struct{ x, y float64 }
 
-func (p point) X() float64 { return p.x }
-func (p point) Y() float64 { return p.y }
-func (p point) Z() float64 { return 0 }
-func (p point) M() float64 { return 0 }
+func (p point) X() float64     { return p.x }
+func (p point) Y() float64     { return p.y }
+func (p point) Z() float64     { return 0 }
+func (p point) M() float64     { return 0 }
+func (p point) Layout() Layout { return XY }
 
 // NewPoint returns new 2 dimensions point
 func NewPoint(x, y float64) Point {
@@ -29,10 +99,11 @@ func NewPoint(x, y float64) Point {
 
 type pointZ struct{ x, y, z float64 }
 
-func (p pointZ) X() float64 { return p.x }
-func (p pointZ) Y() float64 { return p.y }
-func (p pointZ) Z() float64 { return p.z }
-func (p pointZ) M() float64 { return 0 }
+func (p pointZ) X() float64     { return p.x }
+func (p pointZ) Y() float64     { return p.y }
+func (p pointZ) Z() float64     { return p.z }
+func (p pointZ) M() float64     { return 0 }
+func (p pointZ) Layout() Layout { return XYZ }
 
 // NewPointZ returns new 3 dimensions point with Z dimension
 func NewPointZ(x, y, z float64) Point {
@@ -41,10 +112,11 @@ func NewPointZ(x, y, z float64) Point {
 
 type pointM struct{ x, y, m float64 }
 
-func (p pointM) X() float64 { return p.x }
-func (p pointM) Y() float64 { return p.y }
-func (p pointM) Z() float64 { return 0 }
-func (p pointM) M() float64 { return p.m }
+func (p pointM) X() float64     { return p.x }
+func (p pointM) Y() float64     { return p.y }
+func (p pointM) Z() float64     { return 0 }
+func (p pointM) M() float64     { return p.m }
+func (p pointM) Layout() Layout { return XYM }
 
 // NewPointM returns new 3 dimensions point with M dimension
 func NewPointM(x, y, m float64) Point {
@@ -53,10 +125,11 @@ func NewPointM(x, y, m float64) Point {
 
 type pointZM struct{ x, y, z, m float64 }
 
-func (p pointZM) X() float64 { return p.x }
-func (p pointZM) Y() float64 { return p.y }
-func (p pointZM) Z() float64 { return p.z }
-func (p pointZM) M() float64 { return p.m }
+func (p pointZM) X() float64     { return p.x }
+func (p pointZM) Y() float64     { return p.y }
+func (p pointZM) Z() float64     { return p.z }
+func (p pointZM) M() float64     { return p.m }
+func (p pointZM) Layout() Layout { return XYZM }
 
 // NewPointZM returns new 4 dimensions point
 func NewPointZM(x, y, z, m float64) Point {
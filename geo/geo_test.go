@@ -192,3 +192,49 @@ func checkMultiPoint(t *testing.T, mp, emp MultiPoint, prefix string) {
 		checkPoint(t, p, ep.X(), ep.Y(), ep.Z(), ep.M(), fmt.Sprintf("%sPoint %d: ", prefix, idx))
 	}
 }
+
+func TestLayout(t *testing.T) {
+	cases := []struct {
+		point  Point
+		layout Layout
+		hasZ   bool
+		hasM   bool
+		stride int
+		name   string
+	}{
+		{NewPoint(1, 2), XY, false, false, 2, "XY"},
+		{NewPointZ(1, 2, 3), XYZ, true, false, 3, "XYZ"},
+		{NewPointM(1, 2, 3), XYM, false, true, 3, "XYM"},
+		{NewPointZM(1, 2, 3, 4), XYZM, true, true, 4, "XYZM"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			l := c.point.Layout()
+			if l != c.layout {
+				t.Errorf("Expected %v, got %v\n", c.layout, l)
+			}
+
+			if nl := NewLayout(c.hasZ, c.hasM); nl != c.layout {
+				t.Errorf("Expected %v, got %v\n", c.layout, nl)
+			}
+
+			if l.HasZ() != c.hasZ || l.HasM() != c.hasM {
+				t.Errorf("Expected Z %v and M %v, got Z %v and M %v\n", c.hasZ, c.hasM, l.HasZ(), l.HasM())
+			}
+
+			if l.Stride() != c.stride {
+				t.Errorf("Expected %v, got %v\n", c.stride, l.Stride())
+			}
+
+			if l.String() != c.name {
+				t.Errorf("Expected %v, got %v\n", c.name, l.String())
+			}
+
+			seq := NewSequence(make([]float64, c.stride), c.hasZ, c.hasM)
+			if pl := seq.Point(0).Layout(); pl != c.layout {
+				t.Errorf("Expected %v, got %v\n", c.layout, pl)
+			}
+		})
+	}
+}
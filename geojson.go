@@ -0,0 +1,634 @@
+package ewkb
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/kcasctiv/go-ewkb/geo"
+)
+
+// GeoJSONDefaultSRID is SRID, assigned to geometries decoded from GeoJSON.
+// RFC 7946 coordinates are always WGS 84, so it is 4326 by default.
+// Zero value means decoded geometries have no SRID
+var GeoJSONDefaultSRID int32 = 4326
+
+// GeoJSON geometry type names
+const (
+	geoJSONPoint        = "Point"
+	geoJSONLine         = "LineString"
+	geoJSONPolygon      = "Polygon"
+	geoJSONMultiPoint   = "MultiPoint"
+	geoJSONMultiLine    = "MultiLineString"
+	geoJSONMultiPolygon = "MultiPolygon"
+	geoJSONCollection   = "GeometryCollection"
+)
+
+// geoJSONTypes holds geometry types of GeoJSON type names
+var geoJSONTypes = map[string]uint32{
+	geoJSONPoint:        PointType,
+	geoJSONLine:         LineType,
+	geoJSONPolygon:      PolygonType,
+	geoJSONMultiPoint:   MultiPointType,
+	geoJSONMultiLine:    MultiLineType,
+	geoJSONMultiPolygon: MultiPolygonType,
+	geoJSONCollection:   CollectionType,
+}
+
+// errGeoJSONPosition is returned by encoding of point with NaN X or Y,
+// including empty point, as position of line, polygon or multi point.
+// JSON has no NaN, and RFC 7946 position must have at least two numbers
+var errGeoJSONPosition = errors.New("geojson: position with NaN X or Y can not be represented")
+
+// ErrUnsupportedGeoJSON is returned by GeoJSON encoding and decoding
+// of geometry, type of which is not defined by RFC 7946
+var ErrUnsupportedGeoJSON = errors.New("geojson: unsupported geometry type")
+
+// unsupportedGeoJSON returns ErrUnsupportedGeoJSON for geometry type
+func unsupportedGeoJSON(typ uint32) error {
+	return fmt.Errorf("%w %d", ErrUnsupportedGeoJSON, typ)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+// Produces RFC 7946 GeoJSON geometry object.
+// Z dimension is written as third position element,
+// M dimension is not supported by GeoJSON and is dropped.
+// Empty point is written with empty coordinates
+func (p *Point) MarshalJSON() ([]byte, error) {
+	if p.IsEmpty() {
+		return marshalGeoJSON(geoJSONPoint, []float64{})
+	}
+
+	pos, err := positionGeoJSON(p, p.HasZ())
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalGeoJSON(geoJSONPoint, pos)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (p *Point) UnmarshalJSON(data []byte) error {
+	g, err := unmarshalGeoJSONType(data, geoJSONPoint)
+	if err != nil {
+		return err
+	}
+
+	*p = *g.(*Point)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler interface.
+// Produces RFC 7946 GeoJSON geometry object.
+// Z dimension is written as third position element,
+// M dimension is not supported by GeoJSON and is dropped.
+// Empty points can not be represented, so they are rejected
+func (l *LineString) MarshalJSON() ([]byte, error) {
+	points, err := multiPointGeoJSON(l, l.HasZ())
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalGeoJSON(geoJSONLine, points)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (l *LineString) UnmarshalJSON(data []byte) error {
+	g, err := unmarshalGeoJSONType(data, geoJSONLine)
+	if err != nil {
+		return err
+	}
+
+	*l = *g.(*LineString)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler interface.
+// Produces RFC 7946 GeoJSON geometry object.
+// Z dimension is written as third position element,
+// M dimension is not supported by GeoJSON and is dropped.
+// Empty points can not be represented, so they are rejected
+func (p *Polygon) MarshalJSON() ([]byte, error) {
+	rings, err := polygonGeoJSON(p, p.HasZ())
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalGeoJSON(geoJSONPolygon, rings)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (p *Polygon) UnmarshalJSON(data []byte) error {
+	g, err := unmarshalGeoJSONType(data, geoJSONPolygon)
+	if err != nil {
+		return err
+	}
+
+	*p = *g.(*Polygon)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler interface.
+// Produces RFC 7946 GeoJSON geometry object.
+// Z dimension is written as third position element,
+// M dimension is not supported by GeoJSON and is dropped.
+// Empty points can not be represented, so they are rejected
+func (p *MultiPoint) MarshalJSON() ([]byte, error) {
+	points, err := multiPointGeoJSON(p, p.HasZ())
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalGeoJSON(geoJSONMultiPoint, points)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (p *MultiPoint) UnmarshalJSON(data []byte) error {
+	g, err := unmarshalGeoJSONType(data, geoJSONMultiPoint)
+	if err != nil {
+		return err
+	}
+
+	*p = *g.(*MultiPoint)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler interface.
+// Produces RFC 7946 GeoJSON geometry object.
+// Z dimension is written as third position element,
+// M dimension is not supported by GeoJSON and is dropped.
+// Empty points can not be represented, so they are rejected
+func (l *MultiLineString) MarshalJSON() ([]byte, error) {
+	lines := make([][][]float64, l.Len())
+	for idx := range lines {
+		var err error
+		if lines[idx], err = multiPointGeoJSON(l.Line(idx), l.HasZ()); err != nil {
+			return nil, err
+		}
+	}
+
+	return marshalGeoJSON(geoJSONMultiLine, lines)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (l *MultiLineString) UnmarshalJSON(data []byte) error {
+	g, err := unmarshalGeoJSONType(data, geoJSONMultiLine)
+	if err != nil {
+		return err
+	}
+
+	*l = *g.(*MultiLineString)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler interface.
+// Produces RFC 7946 GeoJSON geometry object.
+// Z dimension is written as third position element,
+// M dimension is not supported by GeoJSON and is dropped.
+// Empty points can not be represented, so they are rejected
+func (p *MultiPolygon) MarshalJSON() ([]byte, error) {
+	pols := make([][][][]float64, p.Len())
+	for idx := range pols {
+		var err error
+		if pols[idx], err = polygonGeoJSON(p.Polygon(idx), p.HasZ()); err != nil {
+			return nil, err
+		}
+	}
+
+	return marshalGeoJSON(geoJSONMultiPolygon, pols)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (p *MultiPolygon) UnmarshalJSON(data []byte) error {
+	g, err := unmarshalGeoJSONType(data, geoJSONMultiPolygon)
+	if err != nil {
+		return err
+	}
+
+	*p = *g.(*MultiPolygon)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler interface.
+// Produces RFC 7946 GeoJSON geometry object
+func (c *GeometryCollection) MarshalJSON() ([]byte, error) {
+	geoms := make([]json.RawMessage, c.Len())
+	for idx := range geoms {
+		if c.Geometry(idx) == nil {
+			return nil, nested(ErrNilMember, "geometry", idx)
+		}
+
+		m, ok := c.Geometry(idx).(json.Marshaler)
+		if !ok {
+			return nil, unsupportedGeoJSON(c.Geometry(idx).Type())
+		}
+
+		b, err := m.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		geoms[idx] = b
+	}
+
+	return json.Marshal(struct {
+		Type       string            `json:"type"`
+		Geometries []json.RawMessage `json:"geometries"`
+	}{geoJSONCollection, geoms})
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (c *GeometryCollection) UnmarshalJSON(data []byte) error {
+	g, err := unmarshalGeoJSONType(data, geoJSONCollection)
+	if err != nil {
+		return err
+	}
+
+	*c = *g.(*GeometryCollection)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler interface.
+// CircularString is not supported by GeoJSON,
+// so ErrUnsupportedGeoJSON is returned
+func (c *CircularString) MarshalJSON() ([]byte, error) {
+	return nil, unsupportedGeoJSON(CircularStringType)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+// CircularString is not supported by GeoJSON,
+// so ErrUnsupportedGeoJSON is returned
+func (c *CircularString) UnmarshalJSON([]byte) error {
+	return unsupportedGeoJSON(CircularStringType)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+// CompoundCurve is not supported by GeoJSON,
+// so ErrUnsupportedGeoJSON is returned
+func (c *CompoundCurve) MarshalJSON() ([]byte, error) {
+	return nil, unsupportedGeoJSON(CompoundCurveType)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+// CompoundCurve is not supported by GeoJSON,
+// so ErrUnsupportedGeoJSON is returned
+func (c *CompoundCurve) UnmarshalJSON([]byte) error {
+	return unsupportedGeoJSON(CompoundCurveType)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+// CurvePolygon is not supported by GeoJSON,
+// so ErrUnsupportedGeoJSON is returned
+func (p *CurvePolygon) MarshalJSON() ([]byte, error) {
+	return nil, unsupportedGeoJSON(CurvePolygonType)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+// CurvePolygon is not supported by GeoJSON,
+// so ErrUnsupportedGeoJSON is returned
+func (p *CurvePolygon) UnmarshalJSON([]byte) error {
+	return unsupportedGeoJSON(CurvePolygonType)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+// MultiCurve is not supported by GeoJSON,
+// so ErrUnsupportedGeoJSON is returned
+func (c *MultiCurve) MarshalJSON() ([]byte, error) {
+	return nil, unsupportedGeoJSON(MultiCurveType)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+// MultiCurve is not supported by GeoJSON,
+// so ErrUnsupportedGeoJSON is returned
+func (c *MultiCurve) UnmarshalJSON([]byte) error {
+	return unsupportedGeoJSON(MultiCurveType)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+// MultiSurface is not supported by GeoJSON,
+// so ErrUnsupportedGeoJSON is returned
+func (p *MultiSurface) MarshalJSON() ([]byte, error) {
+	return nil, unsupportedGeoJSON(MultiSurfaceType)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+// MultiSurface is not supported by GeoJSON,
+// so ErrUnsupportedGeoJSON is returned
+func (p *MultiSurface) UnmarshalJSON([]byte) error {
+	return unsupportedGeoJSON(MultiSurfaceType)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+// PolyhedralSurface is not supported by GeoJSON,
+// so ErrUnsupportedGeoJSON is returned
+func (p *PolyhedralSurface) MarshalJSON() ([]byte, error) {
+	return nil, unsupportedGeoJSON(PolyhedralSurfaceType)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+// PolyhedralSurface is not supported by GeoJSON,
+// so ErrUnsupportedGeoJSON is returned
+func (p *PolyhedralSurface) UnmarshalJSON([]byte) error {
+	return unsupportedGeoJSON(PolyhedralSurfaceType)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+// Tin is not supported by GeoJSON,
+// so ErrUnsupportedGeoJSON is returned
+func (t *Tin) MarshalJSON() ([]byte, error) {
+	return nil, unsupportedGeoJSON(TinType)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+// Tin is not supported by GeoJSON,
+// so ErrUnsupportedGeoJSON is returned
+func (t *Tin) UnmarshalJSON([]byte) error {
+	return unsupportedGeoJSON(TinType)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+// Triangle is not supported by GeoJSON,
+// so ErrUnsupportedGeoJSON is returned
+func (t *Triangle) MarshalJSON() ([]byte, error) {
+	return nil, unsupportedGeoJSON(TriangleType)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+// Triangle is not supported by GeoJSON,
+// so ErrUnsupportedGeoJSON is returned
+func (t *Triangle) UnmarshalJSON([]byte) error {
+	return unsupportedGeoJSON(TriangleType)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+// Null geometry is marshaled as JSON null. Value receiver
+// is used, so that Wrapper, held by value, is marshaled too
+func (w Wrapper) MarshalJSON() ([]byte, error) {
+	if w.Geometry == nil {
+		return []byte("null"), nil
+	}
+
+	m, ok := w.Geometry.(json.Marshaler)
+	if !ok {
+		return nil, unsupportedGeoJSON(w.Geometry.Type())
+	}
+
+	return m.MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+// JSON null is unmarshaled as null geometry
+func (w *Wrapper) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		w.Geometry = nil
+		return nil
+	}
+
+	g, err := unmarshalGeoJSON(data, GeoJSONDefaultSRID != 0, GeoJSONDefaultSRID)
+	if err != nil {
+		w.Geometry = nil
+		return err
+	}
+
+	w.Geometry = g
+	return nil
+}
+
+func marshalGeoJSON(typ string, coords interface{}) ([]byte, error) {
+	return json.Marshal(struct {
+		Type        string      `json:"type"`
+		Coordinates interface{} `json:"coordinates"`
+	}{typ, coords})
+}
+
+// positionGeoJSON returns GeoJSON position of point.
+// NaN Z coordinate can't be represented in JSON,
+// so such point is written as 2D position.
+// Point with NaN X or Y is rejected
+func positionGeoJSON(p geo.Point, hasZ bool) ([]float64, error) {
+	if math.IsNaN(p.X()) || math.IsNaN(p.Y()) {
+		return nil, errGeoJSONPosition
+	}
+
+	if hasZ && !math.IsNaN(p.Z()) {
+		return []float64{p.X(), p.Y(), p.Z()}, nil
+	}
+
+	return []float64{p.X(), p.Y()}, nil
+}
+
+func multiPointGeoJSON(mp geo.MultiPoint, hasZ bool) ([][]float64, error) {
+	points := make([][]float64, mp.Len())
+	for idx := range points {
+		var err error
+		if points[idx], err = positionGeoJSON(mp.Point(idx), hasZ); err != nil {
+			return nil, err
+		}
+	}
+
+	return points, nil
+}
+
+func polygonGeoJSON(p geo.Polygon, hasZ bool) ([][][]float64, error) {
+	rings := make([][][]float64, p.Len())
+	for idx := range rings {
+		var err error
+		if rings[idx], err = multiPointGeoJSON(p.Ring(idx), hasZ); err != nil {
+			return nil, err
+		}
+	}
+
+	return rings, nil
+}
+
+type geoJSONObject struct {
+	Type        string            `json:"type"`
+	Coordinates json.RawMessage   `json:"coordinates"`
+	Geometries  []json.RawMessage `json:"geometries"`
+}
+
+func unmarshalGeoJSONType(data []byte, typ string) (Geometry, error) {
+	var obj geoJSONObject
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+
+	if obj.Type != typ {
+		actual, ok := geoJSONTypes[obj.Type]
+		if !ok {
+			return nil, fmt.Errorf("geojson: unknown geometry type %q", obj.Type)
+		}
+
+		return nil, &TypeMismatchError{Expected: geoJSONTypes[typ], Actual: actual}
+	}
+
+	return decodeGeoJSON(&obj, GeoJSONDefaultSRID != 0, GeoJSONDefaultSRID)
+}
+
+func unmarshalGeoJSON(data []byte, hasSRID bool, srid int32) (Geometry, error) {
+	var obj geoJSONObject
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+
+	return decodeGeoJSON(&obj, hasSRID, srid)
+}
+
+func decodeGeoJSON(obj *geoJSONObject, hasSRID bool, srid int32) (Geometry, error) {
+	var dims coordDims
+	base := func() Base { return NewBase(NDR, dims.z, dims.m, hasSRID, srid) }
+
+	if obj.Type == geoJSONCollection {
+		geoms := make([]Geometry, len(obj.Geometries))
+		for idx, raw := range obj.Geometries {
+			g, err := unmarshalGeoJSON(raw, false, 0)
+			if err != nil {
+				return nil, err
+			}
+			geoms[idx] = g
+
+			// Dimensions of empty members are not known
+			if g.IsEmpty() {
+				continue
+			}
+
+			member := coordDims{known: true, z: g.HasZ(), m: g.HasM()}
+			if !dims.known {
+				dims = member
+			} else if member != dims {
+				return nil, fmt.Errorf("geojson: geometry %d: %w", idx, &DimensionMismatchError{
+					Expected: geo.NewLayout(dims.z, dims.m),
+					Actual:   geo.NewLayout(member.z, member.m),
+				})
+			}
+		}
+
+		// Empty members get dimensions of collection,
+		// so that it can be encoded
+		for idx, g := range geoms {
+			if g.HasZ() != dims.z || g.HasM() != dims.m {
+				geoms[idx] = forcer{hasZ: dims.z, hasM: dims.m}.geometry(g)
+			}
+		}
+
+		g := NewGeometryCollection(base(), geoms)
+		return &g, nil
+	}
+
+	if len(obj.Coordinates) == 0 {
+		return nil, errors.New("geojson: missing coordinates")
+	}
+
+	switch obj.Type {
+	case geoJSONPoint:
+		var pos []float64
+		if err := json.Unmarshal(obj.Coordinates, &pos); err != nil {
+			return nil, err
+		}
+
+		var pt geo.Point
+		if len(pos) == 0 {
+			pt = dims.emptyPoint()
+		} else {
+			var err error
+			if pt, err = dims.geoJSONPoint(pos); err != nil {
+				return nil, err
+			}
+		}
+
+		g := NewPoint(base(), pt)
+		return &g, nil
+	case geoJSONLine, geoJSONMultiPoint:
+		var pos [][]float64
+		if err := json.Unmarshal(obj.Coordinates, &pos); err != nil {
+			return nil, err
+		}
+
+		mp, err := dims.geoJSONMultiPoint(pos)
+		if err != nil {
+			return nil, err
+		}
+
+		if obj.Type == geoJSONLine {
+			g := NewLineString(base(), mp)
+			return &g, nil
+		}
+		g := NewMultiPoint(base(), mp)
+		return &g, nil
+	case geoJSONPolygon, geoJSONMultiLine:
+		var pos [][][]float64
+		if err := json.Unmarshal(obj.Coordinates, &pos); err != nil {
+			return nil, err
+		}
+
+		lines, err := dims.geoJSONMultiPoints(pos)
+		if err != nil {
+			return nil, err
+		}
+
+		if obj.Type == geoJSONPolygon {
+			g := NewPolygon(base(), geo.NewPolygon(lines))
+			return &g, nil
+		}
+		g := NewMultiLineString(base(), geo.NewMultiLine(lines))
+		return &g, nil
+	case geoJSONMultiPolygon:
+		var pos [][][][]float64
+		if err := json.Unmarshal(obj.Coordinates, &pos); err != nil {
+			return nil, err
+		}
+
+		pols := make([]geo.Polygon, len(pos))
+		for idx := range pos {
+			rings, err := dims.geoJSONMultiPoints(pos[idx])
+			if err != nil {
+				return nil, err
+			}
+			pols[idx] = geo.NewPolygon(rings)
+		}
+
+		g := NewMultiPolygon(base(), geo.NewMultiPolygon(pols))
+		return &g, nil
+	default:
+		return nil, fmt.Errorf("geojson: unknown geometry type %q", obj.Type)
+	}
+}
+
+// geoJSONPoint converts GeoJSON position to point.
+// Third element is treated as Z, fourth one as M
+func (d *coordDims) geoJSONPoint(pos []float64) (geo.Point, error) {
+	if !d.known && !d.infer(len(pos)) {
+		return nil, fmt.Errorf("geojson: position must have 2 to 4 elements, got %d", len(pos))
+	}
+
+	if len(pos) != d.count() {
+		return nil, fmt.Errorf("geojson: position must have %d elements, got %d", d.count(), len(pos))
+	}
+
+	return d.point(pos), nil
+}
+
+func (d *coordDims) geoJSONMultiPoint(pos [][]float64) (geo.MultiPoint, error) {
+	points := make([]geo.Point, len(pos))
+	var err error
+	for idx := range pos {
+		if points[idx], err = d.geoJSONPoint(pos[idx]); err != nil {
+			return nil, err
+		}
+	}
+
+	return geo.NewMultiPoint(points), nil
+}
+
+func (d *coordDims) geoJSONMultiPoints(pos [][][]float64) ([]geo.MultiPoint, error) {
+	mps := make([]geo.MultiPoint, len(pos))
+	var err error
+	for idx := range pos {
+		if mps[idx], err = d.geoJSONMultiPoint(pos[idx]); err != nil {
+			return nil, err
+		}
+	}
+
+	return mps, nil
+}
@@ -0,0 +1,419 @@
+package ewkb
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/kcasctiv/go-ewkb/geo"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	cases := []struct {
+		name     string
+		geom     string
+		expected string
+	}{
+		{"point", "SRID=4326;POINT(1 2)", `{"type":"Point","coordinates":[1,2]}`},
+		{"point with Z dimension", "POINT(1 2 3)", `{"type":"Point","coordinates":[1,2,3]}`},
+		{"point with M dimension", "POINTM(1 2 3)", `{"type":"Point","coordinates":[1,2]}`},
+		{"point with Z and M dimensions", "POINT(1 2 3 4)", `{"type":"Point","coordinates":[1,2,3]}`},
+		{"point empty", "POINT EMPTY", `{"type":"Point","coordinates":[]}`},
+		{"line", "LINESTRING(1 2,3 4)", `{"type":"LineString","coordinates":[[1,2],[3,4]]}`},
+		{
+			"polygon",
+			"POLYGON((1 2,3 4,5 6,1 2))",
+			`{"type":"Polygon","coordinates":[[[1,2],[3,4],[5,6],[1,2]]]}`,
+		},
+		{"multipoint", "MULTIPOINT(1 2,3 4)", `{"type":"MultiPoint","coordinates":[[1,2],[3,4]]}`},
+		{
+			"multiline",
+			"MULTILINESTRING((1 2,3 4),(5 6,7 8))",
+			`{"type":"MultiLineString","coordinates":[[[1,2],[3,4]],[[5,6],[7,8]]]}`,
+		},
+		{
+			"multipolygon",
+			"MULTIPOLYGON(((1 2,3 4,5 6,1 2)))",
+			`{"type":"MultiPolygon","coordinates":[[[[1,2],[3,4],[5,6],[1,2]]]]}`,
+		},
+		{"multipolygon empty", "MULTIPOLYGON EMPTY", `{"type":"MultiPolygon","coordinates":[]}`},
+		{
+			"collection",
+			"GEOMETRYCOLLECTION(POINT(1 2),LINESTRING(1 2,3 4))",
+			`{"type":"GeometryCollection","geometries":[` +
+				`{"type":"Point","coordinates":[1,2]},` +
+				`{"type":"LineString","coordinates":[[1,2],[3,4]]}]}`,
+		},
+		{"collection empty", "GEOMETRYCOLLECTION EMPTY", `{"type":"GeometryCollection","geometries":[]}`},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			g, err := ParseEWKT(c.geom)
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			b, err := json.Marshal(g)
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			if string(b) != c.expected {
+				t.Errorf("Expected %s, got %s\n", c.expected, b)
+			}
+		})
+	}
+}
+
+func TestMarshalJSON_NaNZ(t *testing.T) {
+	base := NewBase(NDR, true, false, false, 0)
+	nan := math.NaN()
+	p := NewPoint(base, geo.NewPointZ(1, 2, nan))
+	mp := NewMultiPoint(base, geo.NewMultiPoint([]geo.Point{
+		geo.NewPointZ(1, 2, 3),
+		geo.NewPointZ(3, 4, nan),
+	}))
+	gc := NewGeometryCollection(base, []Geometry{&p})
+	cases := []struct {
+		name     string
+		geom     Geometry
+		expected string
+	}{
+		{"point", &p, `{"type":"Point","coordinates":[1,2]}`},
+		{"multipoint", &mp, `{"type":"MultiPoint","coordinates":[[1,2,3],[3,4]]}`},
+		{
+			"collection",
+			&gc,
+			`{"type":"GeometryCollection","geometries":[{"type":"Point","coordinates":[1,2]}]}`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			b, err := json.Marshal(c.geom)
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			if string(b) != c.expected {
+				t.Errorf("Expected %s, got %s\n", c.expected, b)
+			}
+		})
+	}
+}
+
+func TestMarshalJSON_RoundTrip(t *testing.T) {
+	geoms := []string{
+		"SRID=4326;POINT(1 2)",
+		"SRID=4326;POINT EMPTY",
+		"SRID=4326;POINT(1 2 3)",
+		"SRID=4326;LINESTRING(1 2,3 4)",
+		"SRID=4326;POLYGON((0 0,0 1,1 1,0 0))",
+		"SRID=4326;MULTIPOINT(1 2,3 4)",
+		"SRID=4326;MULTILINESTRING((1 2,3 4),(5 6,7 8))",
+		"SRID=4326;MULTIPOLYGON(((0 0,0 1,1 1,0 0)),EMPTY)",
+		"SRID=4326;GEOMETRYCOLLECTION(POINT(1 2),POINT EMPTY)",
+	}
+
+	for _, src := range geoms {
+		t.Run(src, func(t *testing.T) {
+			var w Wrapper
+			if err := w.UnmarshalText([]byte(src)); err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			b, err := json.Marshal(&w)
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			var decoded Wrapper
+			if err = json.Unmarshal(b, &decoded); err != nil {
+				t.Fatalf("Expected: no errors, got error: %v (%s)\n", err, b)
+			}
+
+			if s := decoded.Geometry.String(); s != src {
+				t.Errorf("Expected %q, got %q\n", src, s)
+			}
+		})
+	}
+}
+
+func TestMarshalJSON_NaNPosition(t *testing.T) {
+	base := NewBase(NDR, false, false, false, 0)
+	nan := math.NaN()
+	p := NewPoint(base, geo.NewPoint(nan, 2))
+	mp := NewMultiPoint(base, geo.NewMultiPoint([]geo.Point{geo.NewPoint(1, 2), geo.NewPoint(nan, nan)}))
+	line := NewLineString(base, geo.NewMultiPoint([]geo.Point{geo.NewPoint(1, 2), geo.NewPoint(nan, nan)}))
+	poly := NewPolygon(base, geo.NewPolygon([]geo.MultiPoint{line.mp}))
+	ml := NewMultiLineString(base, geo.NewMultiLine([]geo.MultiPoint{line.mp}))
+	mpoly := NewMultiPolygon(base, geo.NewMultiPolygon([]geo.Polygon{poly.poly}))
+	gc := NewGeometryCollection(base, []Geometry{&line})
+
+	for _, g := range []Geometry{&p, &mp, &line, &poly, &ml, &mpoly, &gc} {
+		t.Run(g.String(), func(t *testing.T) {
+			if _, err := json.Marshal(g); !errors.Is(err, errGeoJSONPosition) {
+				t.Errorf("Expected %v, got %v\n", errGeoJSONPosition, err)
+			}
+		})
+	}
+}
+
+func TestMarshalJSON_Unsupported(t *testing.T) {
+	cases := []string{
+		"CIRCULARSTRING(0 0,1 1,2 0)",
+		"COMPOUNDCURVE((0 0,1 1),CIRCULARSTRING(1 1,2 2,3 1))",
+		"CURVEPOLYGON(CIRCULARSTRING(0 0,1 1,2 0,1 -1,0 0))",
+		"MULTICURVE((0 0,1 1),CIRCULARSTRING(1 1,2 2,3 1))",
+		"MULTISURFACE(((0 0,1 1,1 0,0 0)))",
+		"POLYHEDRALSURFACE(((0 0 0,0 1 0,1 1 0,0 0 0)))",
+		"TIN(((0 0 0,0 1 0,1 1 0,0 0 0)))",
+		"TRIANGLE((0 0,0 1,1 1,0 0))",
+	}
+
+	for _, c := range cases {
+		t.Run(c, func(t *testing.T) {
+			g, err := ParseEWKT(c)
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			gc := NewGeometryCollection(NewBase(NDR, false, false, false, 0), []Geometry{g})
+			for _, v := range []interface{}{g, &Wrapper{Geometry: g}, &gc} {
+				if b, err := json.Marshal(v); !errors.Is(err, ErrUnsupportedGeoJSON) {
+					t.Errorf("Expected %v, got %s and %v\n", ErrUnsupportedGeoJSON, b, err)
+				}
+			}
+
+			data := []byte(`{"type":"LineString","coordinates":[[1,2]]}`)
+			if err := json.Unmarshal(data, g); !errors.Is(err, ErrUnsupportedGeoJSON) {
+				t.Errorf("Expected %v, got %v\n", ErrUnsupportedGeoJSON, err)
+			}
+		})
+	}
+}
+
+func TestWrapper_UnmarshalJSON(t *testing.T) {
+	cases := []struct {
+		name     string
+		data     string
+		valid    bool
+		expected string
+	}{
+		{"null", `null`, true, ""},
+		{"point", `{"type":"Point","coordinates":[1,2]}`, true, "SRID=4326;POINT(1 2)"},
+		{"point with Z dimension", `{"type":"Point","coordinates":[1,2,3]}`, true, "SRID=4326;POINT(1 2 3)"},
+		{"point empty", `{"type":"Point","coordinates":[]}`, true, "SRID=4326;POINT EMPTY"},
+		{
+			"line",
+			`{"type":"LineString","coordinates":[[1,2],[3,4]]}`,
+			true,
+			"SRID=4326;LINESTRING(1 2,3 4)",
+		},
+		{
+			"polygon",
+			`{"type":"Polygon","coordinates":[[[1,2],[3,4],[5,6],[1,2]]]}`,
+			true,
+			"SRID=4326;POLYGON((1 2,3 4,5 6,1 2))",
+		},
+		{
+			"multipoint",
+			`{"type":"MultiPoint","coordinates":[[1,2,3],[3,4,5]]}`,
+			true,
+			"SRID=4326;MULTIPOINT(1 2 3,3 4 5)",
+		},
+		{
+			"multiline",
+			`{"type":"MultiLineString","coordinates":[[[1,2],[3,4]],[[5,6],[7,8]]]}`,
+			true,
+			"SRID=4326;MULTILINESTRING((1 2,3 4),(5 6,7 8))",
+		},
+		{
+			"multipolygon",
+			`{"type":"MultiPolygon","coordinates":[[[[1,2],[3,4],[5,6],[1,2]]]]}`,
+			true,
+			"SRID=4326;MULTIPOLYGON(((1 2,3 4,5 6,1 2)))",
+		},
+		{
+			"collection",
+			`{"type":"GeometryCollection","geometries":[{"type":"Point","coordinates":[1,2]}]}`,
+			true,
+			"SRID=4326;GEOMETRYCOLLECTION(POINT(1 2))",
+		},
+		{
+			"collection mixed dimensions",
+			`{"type":"GeometryCollection","geometries":[{"type":"Point","coordinates":[1,2]},` +
+				`{"type":"Point","coordinates":[1,2,3]}]}`,
+			false,
+			"",
+		},
+		{"unknown type", `{"type":"Circle","coordinates":[1,2]}`, false, ""},
+		{"missing coordinates", `{"type":"Point"}`, false, ""},
+		{"mixed dimensions", `{"type":"LineString","coordinates":[[1,2],[3,4,5]]}`, false, ""},
+		{"short position", `{"type":"Point","coordinates":[1]}`, false, ""},
+		{"invalid json", `{"type":`, false, ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var w Wrapper
+			err := json.Unmarshal([]byte(c.data), &w)
+			if err != nil && c.valid {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+			if err == nil && !c.valid {
+				t.Fatal("Expected: error, got: no errors\n")
+			}
+			if !c.valid {
+				return
+			}
+
+			if c.expected == "" {
+				if w.Geometry != nil {
+					t.Errorf("Expected nil geometry, got %v\n", w.Geometry)
+				}
+				return
+			}
+
+			if s := w.Geometry.String(); s != c.expected {
+				t.Errorf("Expected %q, got %q\n", c.expected, s)
+			}
+		})
+	}
+}
+
+func TestGeometryCollection_UnmarshalJSON_Dimensions(t *testing.T) {
+	cases := []struct {
+		name     string
+		data     string
+		expected string
+		err      error
+	}{
+		{
+			"empty member first",
+			`{"type":"GeometryCollection","geometries":[{"type":"Point","coordinates":[]},` +
+				`{"type":"Point","coordinates":[1,2,3]}]}`,
+			"SRID=4326;GEOMETRYCOLLECTION(POINT Z EMPTY,POINT(1 2 3))",
+			nil,
+		},
+		{
+			"nested empty collection",
+			`{"type":"GeometryCollection","geometries":[{"type":"LineString","coordinates":[[1,2,3,4],[5,6,7,8]]},` +
+				`{"type":"GeometryCollection","geometries":[]}]}`,
+			"SRID=4326;GEOMETRYCOLLECTION(LINESTRING(1 2 3 4,5 6 7 8),GEOMETRYCOLLECTION ZM EMPTY)",
+			nil,
+		},
+		{
+			"mixed members",
+			`{"type":"GeometryCollection","geometries":[{"type":"Point","coordinates":[1,2,3]},` +
+				`{"type":"LineString","coordinates":[[1,2],[3,4]]}]}`,
+			"",
+			ErrDimensionMismatch,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var gc GeometryCollection
+			err := json.Unmarshal([]byte(c.data), &gc)
+			if c.err != nil {
+				if !errors.Is(err, c.err) {
+					t.Errorf("Expected %v, got %v\n", c.err, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			if s := gc.String(); s != c.expected {
+				t.Errorf("Expected %q, got %q\n", c.expected, s)
+			}
+
+			if _, err = gc.MarshalBinary(); err != nil {
+				t.Errorf("Expected: no errors, got error: %v\n", err)
+			}
+		})
+	}
+}
+
+func TestWrapper_MarshalJSON(t *testing.T) {
+	var w Wrapper
+	b, err := json.Marshal(&w)
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	if string(b) != "null" {
+		t.Errorf("Expected null, got %s\n", b)
+	}
+
+	p := NewPoint(NewBase(NDR, false, false, true, 4326), geo.NewPoint(1.5, -2))
+	w.Geometry = &p
+	if b, err = json.Marshal(&w); err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	if expected := `{"type":"Point","coordinates":[1.5,-2]}`; string(b) != expected {
+		t.Errorf("Expected %s, got %s\n", expected, b)
+	}
+}
+
+func TestWrapper_MarshalJSON_Field(t *testing.T) {
+	p := NewPoint(NewBase(NDR, false, false, true, 4326), geo.NewPoint(1.5, -2))
+	v := struct {
+		Geom  Wrapper `json:"geom"`
+		Empty Wrapper `json:"empty"`
+	}{Geom: Wrapper{Geometry: &p, ValueFormat: ValueHexEWKB}}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	if expected := `{"geom":{"type":"Point","coordinates":[1.5,-2]},"empty":null}`; string(b) != expected {
+		t.Errorf("Expected %s, got %s\n", expected, b)
+	}
+}
+
+func TestPoint_UnmarshalJSON(t *testing.T) {
+	var p Point
+	err := json.Unmarshal([]byte(`{"type":"LineString","coordinates":[[1,2]]}`), &p)
+	var terr *TypeMismatchError
+	if !errors.Is(err, ErrTypeMismatch) || !errors.As(err, &terr) {
+		t.Fatalf("Expected %v, got %v\n", ErrTypeMismatch, err)
+	}
+
+	if terr.Expected != PointType || terr.Actual != LineType {
+		t.Errorf("Expected %v and %v, got %v and %v\n", PointType, LineType, terr.Expected, terr.Actual)
+	}
+
+	if err := json.Unmarshal([]byte(`{"type":"Point","coordinates":[]}`), &p); err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	if !math.IsNaN(p.X()) || !math.IsNaN(p.Y()) {
+		t.Errorf("Expected empty point, got %v\n", p.String())
+	}
+}
+
+func TestUnmarshalJSON_DefaultSRID(t *testing.T) {
+	defer func(srid int32) { GeoJSONDefaultSRID = srid }(GeoJSONDefaultSRID)
+	GeoJSONDefaultSRID = 0
+
+	var l LineString
+	if err := json.Unmarshal([]byte(`{"type":"LineString","coordinates":[[1,2],[3,4]]}`), &l); err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	if l.HasSRID() {
+		t.Errorf("Expected no SRID, got %v\n", l.SRID())
+	}
+
+	if s := l.String(); s != "LINESTRING(1 2,3 4)" {
+		t.Errorf("Expected %q, got %q\n", "LINESTRING(1 2,3 4)", s)
+	}
+}
@@ -1,11 +1,6 @@
 package ewkb
 
-import (
-	"database/sql/driver"
-	"errors"
-	"fmt"
-	"strings"
-)
+import "database/sql/driver"
 
 // GeometryCollection presents collection of geometry objects
 type GeometryCollection struct {
@@ -14,7 +9,14 @@ type GeometryCollection struct {
 }
 
 // NewGeometryCollection returns new GeometryCollection,
-// created from geometry base and coords data
+// created from geometry base and coords data.
+// Members must have dimensions of base and either have
+// no SRID or have SRID of base. Every member is printed and encoded
+// with its own dimensions and without SRID, which is specified
+// only for the whole collection. Members are not checked
+// by constructor: use Validate to check them. Otherwise encoding
+// of collection with other members fails with
+// ErrDimensionMismatch or ErrSRIDMismatch
 func NewGeometryCollection(b Base, geoms []Geometry) GeometryCollection {
 	return GeometryCollection{
 		header: header{
@@ -30,42 +32,31 @@ func NewGeometryCollection(b Base, geoms []Geometry) GeometryCollection {
 	}
 }
 
+// NewEmptyGeometryCollection returns new empty GeometryCollection,
+// created from geometry base
+func NewEmptyGeometryCollection(b Base) GeometryCollection {
+	return NewGeometryCollection(b, nil)
+}
+
 // Geometry returns geometry with specified index
 func (c *GeometryCollection) Geometry(idx int) Geometry { return c.geoms[idx] }
 
 // Len returns length of collection (count of geometry objects)
 func (c *GeometryCollection) Len() int { return len(c.geoms) }
 
-// String returns WKT/EWKT geometry representation
-func (c *GeometryCollection) String() string {
-	var s string
-	if c.HasSRID() {
-		s = fmt.Sprintf("SRID=%d;", c.srid)
-	}
-	s += "GEOMETRYCOLLECTION"
-	if !c.HasZ() && c.HasM() {
-		s += "M"
-	}
-
-	if c.Len() == 0 {
-		s += " EMPTY"
-		return s
-	}
+// IsEmpty checks if GeometryCollection has no members or all its members are empty
+func (c *GeometryCollection) IsEmpty() bool { return isEmptyGeometries(c.geoms) }
 
-	s += "("
-	for idx := 0; idx < c.Len(); idx++ {
-		gs := c.Geometry(idx).String()
-		if c.Geometry(idx).HasSRID() {
-			splitted := strings.Split(gs, ";")
-			if len(splitted) > 1 {
-				gs = splitted[1]
-			}
-		}
+// Validate checks that dimensions of all points
+// and members match dimensions of geometry
+func (c *GeometryCollection) Validate() error { return validateGeometry(c) }
 
-		s += gs + ","
-	}
+// String returns WKT/EWKT geometry representation
+func (c *GeometryCollection) String() string { return sridPrefix(c) + c.wkt() }
 
-	return s[:len(s)-1] + ")"
+// wkt returns WKT geometry representation
+func (c *GeometryCollection) wkt() string {
+	return "GEOMETRYCOLLECTION" + wktDims(c) + printMembers(c.geoms, 0)
 }
 
 // Scan implements sql.Scanner interface
@@ -75,35 +66,72 @@ func (c *GeometryCollection) Scan(src interface{}) error {
 
 // Value implements sql driver.Valuer interface
 func (c *GeometryCollection) Value() (driver.Value, error) {
-	return c.String(), nil
+	return geometryValue(c, DefaultValueFormat)
 }
 
 // UnmarshalBinary implements encoding.BinaryUnmarshaler interface
 func (c *GeometryCollection) UnmarshalBinary(data []byte) error {
-	h, byteOrder, offset := readHeader(data)
-	if h.Type() != CollectionType {
-		return errors.New("not expected geometry type")
-	}
-
-	geoms, _, err := readCollection(data[offset:], byteOrder)
+	g, err := unmarshal(data, CollectionType, DecodeOptions{})
 	if err != nil {
 		return err
 	}
 
-	c.header = h
-	c.geoms = geoms
-
+	*c = *g.(*GeometryCollection)
 	return nil
 }
 
 // MarshalBinary implements encoding.BinaryMarshaler interface
 func (c *GeometryCollection) MarshalBinary() ([]byte, error) {
-	size := headerSize(c.HasSRID()) + collectionSize(c.geoms, c.HasZ(), c.HasM())
-	b := make([]byte, size)
+	return c.appendBinary(nil, EncodeOptions{})
+}
+
+// AppendBinary appends binary representation of geometry to dst
+// and returns extended slice. Implements encoding.BinaryAppender interface
+func (c *GeometryCollection) AppendBinary(dst []byte) ([]byte, error) {
+	return c.appendBinary(dst, EncodeOptions{})
+}
+
+// EncodedSize returns size of binary representation of geometry
+func (c *GeometryCollection) EncodedSize() int {
+	return c.encodedSize(EncodeOptions{})
+}
+
+func (c *GeometryCollection) encodedSize(opts EncodeOptions) int {
+	// members of unsupported types are rejected by encoding
+	size, _ := collectionSize(c.geoms)
+	return headerSize(c.HasSRID() && !opts.ISO) + size
+}
+
+func (c *GeometryCollection) appendBinary(dst []byte, opts EncodeOptions) ([]byte, error) {
+	if err := validateMembers(c); err != nil {
+		return dst, err
+	}
+
+	size, err := collectionSize(c.geoms)
+	if err != nil {
+		return dst, err
+	}
+
+	hasSRID := c.HasSRID() && !opts.ISO
+	out, b := grow(dst, headerSize(hasSRID)+size)
 
 	byteOrder := getBinaryByteOrder(c.ByteOrder())
-	offset := writeHeader(c, c.Type(), byteOrder, c.HasSRID(), b)
-	writeCollection(c.geoms, byteOrder, c.HasZ(), c.HasM(), b[offset:])
+	offset := writeHeader(c, c.Type(), byteOrder, hasSRID, opts, b)
+	if _, err = writeCollection(c.geoms, byteOrder, opts, b[offset:]); err != nil {
+		return dst, err
+	}
+
+	return out, nil
+}
+
+// isEmptyGeometries checks if all geometry objects are empty.
+// Nil objects are treated as empty
+func isEmptyGeometries(geoms []Geometry) bool {
+	for _, geom := range geoms {
+		if geom != nil && !geom.IsEmpty() {
+			return false
+		}
+	}
 
-	return b, nil
+	return true
 }
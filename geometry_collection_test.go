@@ -0,0 +1,383 @@
+package ewkb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/kcasctiv/go-ewkb/geo"
+)
+
+var nestedCollectionData = []byte{
+	1, 7, 0, 0, 0, 2, 0, 0, 0, 1, 1, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 240, 63, 0, 0, 0, 0, 0, 0,
+	0, 64, 1, 7, 0, 0, 0, 1, 0, 0, 0, 1, 1, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 8, 64, 0, 0, 0, 0,
+	0, 0, 16, 64,
+}
+
+func newNestedCollection() GeometryCollection {
+	base := NewBase(NDR, false, false, false, 0)
+	p1 := NewPoint(base, geo.NewPoint(1, 2))
+	p2 := NewPoint(base, geo.NewPoint(3, 4))
+	inner := NewGeometryCollection(base, []Geometry{&p2})
+	return NewGeometryCollection(base, []Geometry{&p1, &inner})
+}
+
+func TestGeometryCollection_MarshalBinary_Nested(t *testing.T) {
+	c := newNestedCollection()
+	b, err := c.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Expected not errors, got %v\n", err)
+	}
+
+	if !bytes.Equal(b, nestedCollectionData) {
+		t.Errorf("Expected %v, got %v\n", nestedCollectionData, b)
+	}
+}
+
+func TestGeometryCollection_UnmarshalBinary_Nested(t *testing.T) {
+	var c GeometryCollection
+	if err := c.UnmarshalBinary(nestedCollectionData); err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	expected := "GEOMETRYCOLLECTION(POINT(1 2),GEOMETRYCOLLECTION(POINT(3 4)))"
+	if s := c.String(); s != expected {
+		t.Errorf("Expected %q, got %q\n", expected, s)
+	}
+
+	inner, ok := c.Geometry(1).(*GeometryCollection)
+	if !ok {
+		t.Fatalf("Expected *GeometryCollection, got %T\n", c.Geometry(1))
+	}
+
+	if l := inner.Len(); l != 1 {
+		t.Errorf("Len: expected 1, got %v\n", l)
+	}
+}
+
+func TestUnmarshal_MaxDepth(t *testing.T) {
+	cases := []struct {
+		name     string
+		maxDepth int
+		valid    bool
+	}{
+		{"default", 0, true},
+		{"enough", 2, true},
+		{"exceeded", 1, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			g, err := Unmarshal(nestedCollectionData, DecodeOptions{MaxDepth: c.maxDepth})
+			if err != nil && c.valid {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+			if err == nil && !c.valid {
+				t.Fatal("Expected: error, got: no errors\n")
+			}
+			if !c.valid {
+				return
+			}
+
+			if typ := g.Type(); typ != CollectionType {
+				t.Errorf("Type: expected %v, got %v\n", CollectionType, typ)
+			}
+		})
+	}
+}
+
+func TestUnmarshal_DeeplyNested(t *testing.T) {
+	data := []byte{}
+	for idx := 0; idx < DefaultMaxDepth+1; idx++ {
+		data = append(data, 1, 7, 0, 0, 0, 1, 0, 0, 0)
+	}
+	data = append(data, 1, 7, 0, 0, 0, 0, 0, 0, 0)
+
+	if _, err := Unmarshal(data, DecodeOptions{}); err == nil {
+		t.Fatal("Expected: error, got: no errors\n")
+	}
+}
+
+func TestGeometryCollection_String_SRID(t *testing.T) {
+	base := NewBase(NDR, false, false, true, 4326)
+	p := NewPoint(base, geo.NewPoint(1, 2))
+	l := NewLineString(NewBase(NDR, false, false, false, 0), geo.NewMultiPoint([]geo.Point{
+		geo.NewPoint(0, 0), geo.NewPoint(1, 1),
+	}))
+	inner := NewGeometryCollection(base, []Geometry{&p, &l})
+	c := NewGeometryCollection(base, []Geometry{&inner, &p})
+
+	expected := "SRID=4326;GEOMETRYCOLLECTION(GEOMETRYCOLLECTION(POINT(1 2),LINESTRING(0 0,1 1)),POINT(1 2))"
+	if s := c.String(); s != expected {
+		t.Errorf("Expected %q, got %q\n", expected, s)
+	}
+}
+
+func TestGeometryCollection_MarshalBinary_Members(t *testing.T) {
+	base := NewBase(NDR, false, false, true, 4326)
+	p := NewPoint(base, geo.NewPoint(1, 2))
+	pz := NewPoint(NewBase(NDR, true, false, false, 0), geo.NewPointZ(1, 2, 3))
+	p3857 := NewPoint(NewBase(NDR, false, false, true, 3857), geo.NewPoint(1, 2))
+	inner := NewGeometryCollection(NewBase(NDR, false, false, false, 0), []Geometry{&pz})
+	cases := []struct {
+		name  string
+		geoms []Geometry
+		err   error
+		msg   string
+	}{
+		{"same", []Geometry{&p}, nil, ""},
+		{"dimensions", []Geometry{&p, &pz}, ErrDimensionMismatch, "ewkb: dimensions mismatch: expected XY, got XYZ (geometry 1)"},
+		{"SRID", []Geometry{&p3857}, ErrSRIDMismatch, "ewkb: SRID mismatch: expected 4326, got 3857 (geometry 0)"},
+		{"nested", []Geometry{&p, &inner}, ErrDimensionMismatch, "ewkb: dimensions mismatch: expected XY, got XYZ (geometry 1, geometry 0)"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			gc := NewGeometryCollection(base, c.geoms)
+			_, err := gc.MarshalBinary()
+			if c.err == nil {
+				if err != nil {
+					t.Fatalf("Expected: no errors, got error: %v\n", err)
+				}
+				return
+			}
+
+			if !errors.Is(err, c.err) {
+				t.Fatalf("Expected %v, got %v\n", c.err, err)
+			}
+
+			if err.Error() != c.msg {
+				t.Errorf("Expected %q, got %q\n", c.msg, err.Error())
+			}
+
+			if err = gc.Validate(); !errors.Is(err, c.err) {
+				t.Errorf("Expected %v, got %v\n", c.err, err)
+			}
+		})
+	}
+}
+
+func TestGeometryCollection_UnmarshalBinary_Members(t *testing.T) {
+	cases := []struct {
+		name string
+		data string
+		err  error
+	}{
+		{"dimensions", "0107000000010000000101000080000000000000f03f00000000000000400000000000000840", ErrDimensionMismatch},
+		{"SRID", "0107000020e6100000010000000101000020110f0000000000000000f03f0000000000000040", ErrSRIDMismatch},
+		{"same SRID", "0107000020e6100000010000000101000020e6100000000000000000f03f0000000000000040", nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			data, _ := hex.DecodeString(c.data)
+			var gc GeometryCollection
+			err := gc.UnmarshalBinary(data)
+			if !errors.Is(err, c.err) {
+				t.Errorf("Expected %v, got %v\n", c.err, err)
+			}
+
+			if _, err = NewView(data, DecodeOptions{}); !errors.Is(err, c.err) {
+				t.Errorf("Expected %v, got %v\n", c.err, err)
+			}
+		})
+	}
+}
+
+func TestWriteCollection_MemberDimensions(t *testing.T) {
+	zBase := NewBase(NDR, true, false, false, 0)
+	pz := NewPoint(zBase, geo.NewPointZ(1, 2, 3))
+	line := NewLineString(NewBase(NDR, false, true, false, 0), geo.NewMultiPoint([]geo.Point{
+		geo.NewPointM(1, 2, 3),
+		geo.NewPointM(4, 5, 6),
+	}))
+	inner := NewGeometryCollection(zBase, []Geometry{&pz})
+	geoms := []Geometry{&pz, &line, &inner}
+
+	// members are written without validation against
+	// container, so every one must be written as standalone
+	expected := []byte{3, 0, 0, 0}
+	for _, geom := range geoms {
+		b, err := geom.MarshalBinary()
+		if err != nil {
+			t.Fatalf("Expected: no errors, got error: %v\n", err)
+		}
+		expected = append(expected, b...)
+	}
+
+	size, err := collectionSize(geoms)
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+	if size != len(expected) {
+		t.Fatalf("Expected %v, got %v\n", len(expected), size)
+	}
+
+	data := make([]byte, len(expected))
+	n, err := writeCollection(geoms, binary.LittleEndian, EncodeOptions{}, data)
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+	if n != len(expected) {
+		t.Fatalf("Expected %v, got %v\n", len(expected), n)
+	}
+
+	if !bytes.Equal(data, expected) {
+		t.Errorf("Expected %x, got %x\n", expected, data)
+	}
+}
+
+func TestGeometryCollection_MarshalBinary_NilMember(t *testing.T) {
+	base := NewBase(NDR, false, false, false, 0)
+	p := NewPoint(base, geo.NewPoint(1, 2))
+	inner := NewGeometryCollection(base, []Geometry{nil})
+	cases := []struct {
+		name  string
+		geoms []Geometry
+		msg   string
+	}{
+		{"nil", []Geometry{nil}, "ewkb: nil member (geometry 0)"},
+		{"after point", []Geometry{&p, nil}, "ewkb: nil member (geometry 1)"},
+		{"nested", []Geometry{&inner}, "ewkb: nil member (geometry 0, geometry 0)"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			gc := NewGeometryCollection(base, c.geoms)
+			_, err := gc.MarshalBinary()
+			if !errors.Is(err, ErrNilMember) {
+				t.Fatalf("Expected %v, got %v\n", ErrNilMember, err)
+			}
+
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("Expected *ValidationError, got %T\n", err)
+			}
+
+			if err.Error() != c.msg {
+				t.Errorf("Expected %q, got %q\n", c.msg, err.Error())
+			}
+
+			if err = gc.Validate(); !errors.Is(err, ErrNilMember) {
+				t.Errorf("Expected %v, got %v\n", ErrNilMember, err)
+			}
+		})
+	}
+}
+
+func TestGeometryCollection_NilMember(t *testing.T) {
+	base := NewBase(NDR, false, false, false, 0)
+	p := NewPoint(base, geo.NewPoint(1, 2))
+	gc := NewGeometryCollection(base, []Geometry{nil, &p})
+
+	if gc.IsEmpty() {
+		t.Error("Expected: not empty, got: empty\n")
+	}
+
+	expected := "GEOMETRYCOLLECTION(<nil>,POINT(1 2))"
+	if s := gc.String(); s != expected {
+		t.Errorf("Expected %q, got %q\n", expected, s)
+	}
+
+	if _, err := gc.MarshalJSON(); !errors.Is(err, ErrNilMember) {
+		t.Errorf("Expected %v, got %v\n", ErrNilMember, err)
+	}
+
+	expected = "GEOMETRYCOLLECTION(<nil>,POINT(1 2 0))"
+	if s := Force3DZ(&gc, 0).String(); s != expected {
+		t.Errorf("Expected %q, got %q\n", expected, s)
+	}
+
+	expected = "SRID=4326;GEOMETRYCOLLECTION(<nil>,POINT(1 2))"
+	if s := WithSRID(&gc, 4326).String(); s != expected {
+		t.Errorf("Expected %q, got %q\n", expected, s)
+	}
+
+	empty := NewGeometryCollection(base, []Geometry{nil})
+	if !empty.IsEmpty() {
+		t.Error("Expected: empty, got: not empty\n")
+	}
+
+	mc := NewMultiCurve(base, []Geometry{nil})
+	if _, err := Linearize(&mc, 4); !errors.Is(err, ErrNilMember) {
+		t.Errorf("Expected %v, got %v\n", ErrNilMember, err)
+	}
+
+	ms := NewMultiSurface(base, []Geometry{nil})
+	if _, err := Linearize(&ms, 4); !errors.Is(err, ErrNilMember) {
+		t.Errorf("Expected %v, got %v\n", ErrNilMember, err)
+	}
+}
+
+func TestGeometryCollection_UnsupportedMember(t *testing.T) {
+	base := NewBase(NDR, false, false, false, 0)
+	p := NewPoint(base, geo.NewPoint(1, 2))
+	geoms := []Geometry{struct{ *Point }{&p}}
+	gc := NewGeometryCollection(base, geoms)
+
+	if err := gc.Validate(); !errors.Is(err, ErrUnknownType) {
+		t.Errorf("Expected %v, got %v\n", ErrUnknownType, err)
+	}
+
+	if _, err := gc.MarshalBinary(); !errors.Is(err, ErrUnknownType) {
+		t.Errorf("Expected %v, got %v\n", ErrUnknownType, err)
+	}
+
+	if _, err := collectionSize(geoms); !errors.Is(err, ErrUnknownType) {
+		t.Errorf("Expected %v, got %v\n", ErrUnknownType, err)
+	}
+
+	data := make([]byte, 64)
+	if _, err := writeCollection(geoms, binary.LittleEndian, EncodeOptions{}, data); !errors.Is(err, ErrUnknownType) {
+		t.Errorf("Expected %v, got %v\n", ErrUnknownType, err)
+	}
+}
+
+func TestGeometryCollection_MarshalBinary_InvalidTriangle(t *testing.T) {
+	base := NewBase(NDR, false, false, false, 0)
+	valid := geo.NewPolygon([]geo.MultiPoint{geo.NewMultiPoint([]geo.Point{
+		geo.NewPoint(0, 0), geo.NewPoint(0, 1), geo.NewPoint(1, 1), geo.NewPoint(0, 0),
+	})})
+	notClosed := geo.NewPolygon([]geo.MultiPoint{geo.NewMultiPoint([]geo.Point{
+		geo.NewPoint(0, 0), geo.NewPoint(1, 1), geo.NewPoint(2, 2), geo.NewPoint(3, 3),
+	})})
+	short := geo.NewPolygon([]geo.MultiPoint{geo.NewMultiPoint([]geo.Point{
+		geo.NewPoint(0, 0), geo.NewPoint(0, 1), geo.NewPoint(1, 1),
+	})})
+	var (
+		notClosedTriangle = NewTriangle(base, notClosed)
+		shortTriangle     = NewTriangle(base, short)
+		tin               = NewTin(base, geo.NewMultiPolygon([]geo.Polygon{valid, notClosed}))
+	)
+	cases := []struct {
+		name   string
+		member Geometry
+		msg    string
+	}{
+		{"not closed", &notClosedTriangle, "ewkb: triangle must have four closed points (geometry 0)"},
+		{"short", &shortTriangle, "ewkb: triangle must have four closed points (geometry 0)"},
+		{"tin", &tin, "ewkb: triangle must have four closed points (geometry 0, triangle 1)"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			gc := NewGeometryCollection(base, []Geometry{c.member})
+			_, err := gc.MarshalBinary()
+			if !errors.Is(err, ErrInvalidTriangle) {
+				t.Fatalf("Expected %v, got %v\n", ErrInvalidTriangle, err)
+			}
+
+			if err.Error() != c.msg {
+				t.Errorf("Expected %q, got %q\n", c.msg, err.Error())
+			}
+
+			if err = gc.Validate(); !errors.Is(err, ErrInvalidTriangle) {
+				t.Errorf("Expected %v, got %v\n", ErrInvalidTriangle, err)
+			}
+		})
+	}
+}
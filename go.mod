@@ -0,0 +1,3 @@
+module github.com/kcasctiv/go-ewkb
+
+go 1.21
@@ -2,8 +2,6 @@ package ewkb
 
 import (
 	"database/sql/driver"
-	"errors"
-	"fmt"
 
 	"github.com/kcasctiv/go-ewkb/geo"
 )
@@ -31,21 +29,34 @@ func NewLineString(b Base, mp geo.MultiPoint) LineString {
 	}
 }
 
+// NewEmptyLineString returns new empty LineString,
+// created from geometry base
+func NewEmptyLineString(b Base) LineString {
+	return NewLineString(b, geo.NewMultiPoint(nil))
+}
+
 // Point returns point of LineString with specified index
 func (l *LineString) Point(idx int) geo.Point { return l.mp.Point(idx) }
 
 // Len returns length of LineString (count of points)
 func (l *LineString) Len() int { return l.mp.Len() }
 
+// IsEmpty checks if LineString has no points
+func (l *LineString) IsEmpty() bool { return l.Len() == 0 }
+
+// Validate checks that dimensions of all points
+// and members match dimensions of geometry
+func (l *LineString) Validate() error { return validateGeometry(l) }
+
 // String returns WKT/EWKT geometry representation
-func (l *LineString) String() string {
-	var s string
-	if l.HasSRID() {
-		s = fmt.Sprintf("SRID=%d;", l.srid)
-	}
-	s += "LINESTRING"
-	if !l.HasZ() && l.HasM() {
-		s += "M"
+func (l *LineString) String() string { return sridPrefix(l) + l.wkt() }
+
+// wkt returns WKT geometry representation
+func (l *LineString) wkt() string {
+	s := "LINESTRING" + wktDims(l)
+
+	if l.Len() == 0 {
+		return s + " EMPTY"
 	}
 
 	return s + printMultiPoint(l, l.HasZ(), l.HasM())
@@ -58,31 +69,47 @@ func (l *LineString) Scan(src interface{}) error {
 
 // Value implements sql driver.Valuer interface
 func (l *LineString) Value() (driver.Value, error) {
-	return l.String(), nil
+	return geometryValue(l, DefaultValueFormat)
 }
 
 // UnmarshalBinary implements encoding.BinaryUnmarshaler interface
 func (l *LineString) UnmarshalBinary(data []byte) error {
-	h, byteOrder, offset := readHeader(data)
-	if h.Type() != LineType {
-		return errors.New("not expected geometry type")
+	g, err := unmarshal(data, LineType, DecodeOptions{})
+	if err != nil {
+		return err
 	}
 
-	l.header = h
-
-	var err error
-	l.mp, _, err = readMultiPoint(data[offset:], byteOrder, getReadPointFunc(h.wkbType))
-	return err
+	*l = *g.(*LineString)
+	return nil
 }
 
 // MarshalBinary implements encoding.BinaryMarshaler interface
 func (l *LineString) MarshalBinary() ([]byte, error) {
-	size := headerSize(l.HasSRID()) + multiPointSize(l, l.HasZ(), l.HasM())
-	b := make([]byte, size)
+	return l.appendBinary(nil, EncodeOptions{})
+}
+
+// AppendBinary appends binary representation of geometry to dst
+// and returns extended slice. Implements encoding.BinaryAppender interface
+func (l *LineString) AppendBinary(dst []byte) ([]byte, error) {
+	return l.appendBinary(dst, EncodeOptions{})
+}
+
+// EncodedSize returns size of binary representation of geometry
+func (l *LineString) EncodedSize() int {
+	return l.encodedSize(EncodeOptions{})
+}
+
+func (l *LineString) encodedSize(opts EncodeOptions) int {
+	return headerSize(l.HasSRID() && !opts.ISO) + multiPointSize(l, l.HasZ(), l.HasM())
+}
+
+func (l *LineString) appendBinary(dst []byte, opts EncodeOptions) ([]byte, error) {
+	hasSRID := l.HasSRID() && !opts.ISO
+	dst, b := grow(dst, l.encodedSize(opts))
 
 	byteOrder := getBinaryByteOrder(l.ByteOrder())
-	offset := writeHeader(l, l.Type(), byteOrder, l.HasSRID(), b)
+	offset := writeHeader(l, l.Type(), byteOrder, hasSRID, opts, b)
 	writeMultiPoint(l, byteOrder, l.HasZ(), l.HasM(), b[offset:])
 
-	return b, nil
+	return dst, nil
 }
@@ -751,3 +751,39 @@ func TestLineString_MarshalBinary(t *testing.T) {
 		})
 	}
 }
+
+func TestLineString_Marshal_ISO(t *testing.T) {
+	cases := []struct {
+		name     string
+		geom     string
+		expected []byte
+	}{
+		{
+			"line with Z and M dimensions",
+			"LINESTRING(1 2 3 4)",
+			[]byte{
+				1, 186, 11, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 240, 63,
+				0, 0, 0, 0, 0, 0, 0, 64, 0, 0, 0, 0, 0, 0, 8, 64,
+				0, 0, 0, 0, 0, 0, 16, 64,
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			g, err := ParseEWKT(c.geom)
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			b, err := Marshal(g, EncodeOptions{ISO: true})
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			if !bytes.Equal(b, c.expected) {
+				t.Errorf("Expected %v, got %v\n", c.expected, b)
+			}
+		})
+	}
+}
@@ -0,0 +1,258 @@
+package ewkb
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/kcasctiv/go-ewkb/geo"
+)
+
+// Linearize returns geometry, in which curved geometry objects are
+// replaced with their linear approximations: CircularString and
+// CompoundCurve with LineString, CurvePolygon with Polygon,
+// MultiCurve with MultiLineString and MultiSurface with MultiPolygon.
+// Every quarter of circle is approximated with segmentsPerQuarter
+// segments. Members of GeometryCollection are linearized recursively,
+// other geometry objects are returned as is
+func Linearize(g Geometry, segmentsPerQuarter int) (Geometry, error) {
+	if segmentsPerQuarter < 1 {
+		return nil, errors.New("ewkb: segments per quarter must be positive")
+	}
+
+	return linearize(g, segmentsPerQuarter)
+}
+
+func linearize(g Geometry, spq int) (Geometry, error) {
+	switch c := g.(type) {
+	case *CircularString, *CompoundCurve:
+		flat, err := linearizeCurve(c, c.HasZ(), c.HasM(), spq, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		l := NewLineString(c, geo.NewSequence(flat, c.HasZ(), c.HasM()))
+		return &l, nil
+	case *CurvePolygon:
+		poly, err := linearizePolygon(c, c.HasZ(), c.HasM(), spq)
+		if err != nil {
+			return nil, err
+		}
+
+		p := NewPolygon(c, poly)
+		return &p, nil
+	case *MultiCurve:
+		lines := make([]geo.MultiPoint, c.Len())
+		for idx := range lines {
+			flat, err := linearizeCurve(c.Curve(idx), c.HasZ(), c.HasM(), spq, nil)
+			if err != nil {
+				return nil, err
+			}
+			lines[idx] = geo.NewSequence(flat, c.HasZ(), c.HasM())
+		}
+
+		l := NewMultiLineString(c, geo.NewMultiLine(lines))
+		return &l, nil
+	case *MultiSurface:
+		pols := make([]geo.Polygon, c.Len())
+		for idx := range pols {
+			switch s := c.Surface(idx).(type) {
+			case *Polygon:
+				pols[idx] = s.poly
+			case *CurvePolygon:
+				poly, err := linearizePolygon(s, c.HasZ(), c.HasM(), spq)
+				if err != nil {
+					return nil, err
+				}
+				pols[idx] = poly
+			case nil:
+				return nil, nested(ErrNilMember, "surface", idx)
+			default:
+				return nil, fmt.Errorf("ewkb: unexpected surface type %d", s.Type())
+			}
+		}
+
+		p := NewMultiPolygon(c, geo.NewMultiPolygon(pols))
+		return &p, nil
+	case *GeometryCollection:
+		geoms := make([]Geometry, c.Len())
+		for idx := range geoms {
+			geom, err := linearize(c.Geometry(idx), spq)
+			if err != nil {
+				return nil, err
+			}
+			geoms[idx] = geom
+		}
+
+		gc := NewGeometryCollection(c, geoms)
+		return &gc, nil
+	default:
+		return g, nil
+	}
+}
+
+// linearizePolygon returns polygon, rings of which
+// are linear approximations of rings of p
+func linearizePolygon(p *CurvePolygon, hasZ, hasM bool, spq int) (geo.Polygon, error) {
+	var flat []float64
+	ends := make([]int, p.Len())
+	stride := pointSize(hasZ, hasM) / 8
+	for idx := range ends {
+		var err error
+		if flat, err = linearizeCurve(p.Ring(idx), hasZ, hasM, spq, flat); err != nil {
+			return nil, err
+		}
+		ends[idx] = len(flat) / stride
+	}
+
+	return geo.NewFlatPolygon(flat, ends, hasZ, hasM), nil
+}
+
+// linearizeCurve appends coordinates of points, approximating
+// curve with specified dimensions, to dst
+func linearizeCurve(g Geometry, hasZ, hasM bool, spq int, dst []float64) ([]float64, error) {
+	switch c := g.(type) {
+	case *LineString:
+		for idx := 0; idx < c.Len(); idx++ {
+			dst = appendPoint(dst, c.Point(idx), hasZ, hasM)
+		}
+
+		return dst, nil
+	case *CircularString:
+		if c.Len() == 0 {
+			return dst, nil
+		}
+
+		if c.Len() < 3 || c.Len()%2 == 0 {
+			return nil, fmt.Errorf("ewkb: invalid count of CircularString points %d", c.Len())
+		}
+
+		dst = appendPoint(dst, c.Point(0), hasZ, hasM)
+		for idx := 0; idx+2 < c.Len(); idx += 2 {
+			dst = appendArc(dst, c.Point(idx), c.Point(idx+1), c.Point(idx+2), hasZ, hasM, spq)
+		}
+
+		return dst, nil
+	case *CompoundCurve:
+		stride := pointSize(hasZ, hasM) / 8
+		start := len(dst)
+		for idx := 0; idx < c.Len(); idx++ {
+			l := len(dst)
+			var err error
+			if dst, err = linearizeCurve(c.Curve(idx), hasZ, hasM, spq, dst); err != nil {
+				return nil, err
+			}
+
+			// segments are connected, so the first point
+			// of segment repeats the last point of previous one
+			if l > start && len(dst) > l && equalCoords(dst[l-stride:l], dst[l:l+stride]) {
+				dst = append(dst[:l], dst[l+stride:]...)
+			}
+		}
+
+		return dst, nil
+	case nil:
+		return nil, ErrNilMember
+	default:
+		return nil, fmt.Errorf("ewkb: unexpected curve type %d", g.Type())
+	}
+}
+
+// appendArc appends coordinates of points, approximating circular arc,
+// which starts at p0, passes through p1 and ends at p2, to dst.
+// Start point is not appended
+func appendArc(dst []float64, p0, p1, p2 geo.Point, hasZ, hasM bool, spq int) []float64 {
+	// Points are translated, so that p0 is the origin,
+	// to keep precision of arcs far from the origin
+	x0, y0 := p0.X(), p0.Y()
+	x1, y1 := p1.X()-x0, p1.Y()-y0
+	x2, y2 := p2.X()-x0, p2.Y()-y0
+
+	// ux, uy is center of circle relative to p0
+	var ux, uy float64
+	ccw := true
+	if x2 == 0 && y2 == 0 {
+		// full circle, p1 is opposite to p0
+		ux, uy = x1/2, y1/2
+	} else {
+		// cross is twice the signed area of triangle p0, p1, p2.
+		// Points are collinear, if sine of angle at p0 is negligible
+		cross := x1*y2 - y1*x2
+		if math.Abs(cross) <= 1e-12*math.Hypot(x1, y1)*math.Hypot(x2, y2) {
+			dst = appendPoint(dst, p1, hasZ, hasM)
+			return appendPoint(dst, p2, hasZ, hasM)
+		}
+
+		s1, s2 := x1*x1+y1*y1, x2*x2+y2*y2
+		ux = (y2*s1 - y1*s2) / (2 * cross)
+		uy = (x1*s2 - x2*s1) / (2 * cross)
+		ccw = cross > 0
+	}
+
+	cx, cy := x0+ux, y0+uy
+	r := math.Hypot(ux, uy)
+	a0 := math.Atan2(-uy, -ux)
+	a1 := math.Atan2(y1-uy, x1-ux)
+	a2 := math.Atan2(y2-uy, x2-ux)
+
+	var sweep1, sweep float64
+	if ccw {
+		sweep1, sweep = positiveAngle(a1-a0), positiveAngle(a2-a0)
+	} else {
+		sweep1, sweep = -positiveAngle(a0-a1), -positiveAngle(a0-a2)
+	}
+
+	n := int(math.Ceil(math.Abs(sweep) / (math.Pi / 2 / float64(spq))))
+	for idx := 1; idx < n; idx++ {
+		t := sweep * float64(idx) / float64(n)
+		a := a0 + t
+		dst = append(dst, cx+r*math.Cos(a), cy+r*math.Sin(a))
+
+		// Z and M are interpolated linearly by angle
+		// between neighbouring defining points
+		from, to, frac := p0, p1, t/sweep1
+		if math.Abs(t) > math.Abs(sweep1) {
+			from, to, frac = p1, p2, (t-sweep1)/(sweep-sweep1)
+		}
+		if hasZ {
+			dst = append(dst, from.Z()+(to.Z()-from.Z())*frac)
+		}
+		if hasM {
+			dst = append(dst, from.M()+(to.M()-from.M())*frac)
+		}
+	}
+
+	return appendPoint(dst, p2, hasZ, hasM)
+}
+
+// positiveAngle normalizes angle into (0, 2π] range
+func positiveAngle(a float64) float64 {
+	a = math.Mod(a, 2*math.Pi)
+	if a <= 0 {
+		a += 2 * math.Pi
+	}
+
+	return a
+}
+
+func appendPoint(dst []float64, p geo.Point, hasZ, hasM bool) []float64 {
+	dst = append(dst, p.X(), p.Y())
+	if hasZ {
+		dst = append(dst, p.Z())
+	}
+	if hasM {
+		dst = append(dst, p.M())
+	}
+
+	return dst
+}
+
+func equalCoords(a, b []float64) bool {
+	for idx := range a {
+		if a[idx] != b[idx] {
+			return false
+		}
+	}
+
+	return true
+}
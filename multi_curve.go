@@ -0,0 +1,121 @@
+package ewkb
+
+import "database/sql/driver"
+
+// MultiCurve presents MultiCurve geometry object,
+// consisting of LineString, CircularString
+// and CompoundCurve geometry objects
+type MultiCurve struct {
+	header
+	curves []Geometry
+}
+
+// NewMultiCurve returns new MultiCurve,
+// created from geometry base and curves.
+// Curves must match base, as members of GeometryCollection
+func NewMultiCurve(b Base, curves []Geometry) MultiCurve {
+	return MultiCurve{
+		header: header{
+			byteOrder: b.ByteOrder(),
+			wkbType: getFlags(
+				b.HasZ(),
+				b.HasM(),
+				b.HasSRID(),
+			) | MultiCurveType,
+			srid: b.SRID(),
+		},
+		curves: curves,
+	}
+}
+
+// NewEmptyMultiCurve returns new empty MultiCurve,
+// created from geometry base
+func NewEmptyMultiCurve(b Base) MultiCurve {
+	return NewMultiCurve(b, nil)
+}
+
+// Curve returns curve with specified index
+func (c *MultiCurve) Curve(idx int) Geometry { return c.curves[idx] }
+
+// Len returns count of curves
+func (c *MultiCurve) Len() int { return len(c.curves) }
+
+// IsEmpty checks if MultiCurve has no curves or all its curves are empty
+func (c *MultiCurve) IsEmpty() bool { return isEmptyGeometries(c.curves) }
+
+// Validate checks that dimensions of all points
+// and members match dimensions of geometry
+func (c *MultiCurve) Validate() error { return validateGeometry(c) }
+
+// String returns WKT/EWKT geometry representation
+func (c *MultiCurve) String() string { return sridPrefix(c) + c.wkt() }
+
+// wkt returns WKT geometry representation
+func (c *MultiCurve) wkt() string {
+	return "MULTICURVE" + wktDims(c) + printMembers(c.curves, LineType)
+}
+
+// Scan implements sql.Scanner interface
+func (c *MultiCurve) Scan(src interface{}) error {
+	return scanGeometry(src, c)
+}
+
+// Value implements sql driver.Valuer interface
+func (c *MultiCurve) Value() (driver.Value, error) {
+	return geometryValue(c, DefaultValueFormat)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler interface
+func (c *MultiCurve) UnmarshalBinary(data []byte) error {
+	g, err := unmarshal(data, MultiCurveType, DecodeOptions{})
+	if err != nil {
+		return err
+	}
+
+	*c = *g.(*MultiCurve)
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler interface
+func (c *MultiCurve) MarshalBinary() ([]byte, error) {
+	return c.appendBinary(nil, EncodeOptions{})
+}
+
+// AppendBinary appends binary representation of geometry to dst
+// and returns extended slice. Implements encoding.BinaryAppender interface
+func (c *MultiCurve) AppendBinary(dst []byte) ([]byte, error) {
+	return c.appendBinary(dst, EncodeOptions{})
+}
+
+// EncodedSize returns size of binary representation of geometry
+func (c *MultiCurve) EncodedSize() int {
+	return c.encodedSize(EncodeOptions{})
+}
+
+func (c *MultiCurve) encodedSize(opts EncodeOptions) int {
+	// members of unsupported types are rejected by encoding
+	size, _ := collectionSize(c.curves)
+	return headerSize(c.HasSRID() && !opts.ISO) + size
+}
+
+func (c *MultiCurve) appendBinary(dst []byte, opts EncodeOptions) ([]byte, error) {
+	if err := validateMembers(c); err != nil {
+		return dst, err
+	}
+
+	size, err := collectionSize(c.curves)
+	if err != nil {
+		return dst, err
+	}
+
+	hasSRID := c.HasSRID() && !opts.ISO
+	out, b := grow(dst, headerSize(hasSRID)+size)
+
+	byteOrder := getBinaryByteOrder(c.ByteOrder())
+	offset := writeHeader(c, c.Type(), byteOrder, hasSRID, opts, b)
+	if _, err = writeCollection(c.curves, byteOrder, opts, b[offset:]); err != nil {
+		return dst, err
+	}
+
+	return out, nil
+}
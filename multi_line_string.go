@@ -2,8 +2,6 @@ package ewkb
 
 import (
 	"database/sql/driver"
-	"errors"
-	"fmt"
 
 	"github.com/kcasctiv/go-ewkb/geo"
 )
@@ -31,23 +29,40 @@ func NewMultiLineString(b Base, ml geo.MultiLine) MultiLineString {
 	}
 }
 
+// NewEmptyMultiLineString returns new empty MultiLineString,
+// created from geometry base
+func NewEmptyMultiLineString(b Base) MultiLineString {
+	return NewMultiLineString(b, geo.NewMultiLine(nil))
+}
+
 // Line returns line with specified index
 func (l *MultiLineString) Line(idx int) geo.MultiPoint { return l.ml.Line(idx) }
 
 // Len returns count of lines
 func (l *MultiLineString) Len() int { return l.ml.Len() }
 
-// String returns WKT/EWKT geometry representation
-func (l *MultiLineString) String() string {
-	var s string
-	if l.HasSRID() {
-		s = fmt.Sprintf("SRID=%d;", l.srid)
-	}
-	s += "MULTILINESTRING"
-	if !l.HasZ() && l.HasM() {
-		s += "M"
+// IsEmpty checks if MultiLineString has no lines or all its lines are empty
+func (l *MultiLineString) IsEmpty() bool {
+	for idx := 0; idx < l.Len(); idx++ {
+		if l.Line(idx).Len() > 0 {
+			return false
+		}
 	}
 
+	return true
+}
+
+// Validate checks that dimensions of all points
+// and members match dimensions of geometry
+func (l *MultiLineString) Validate() error { return validateGeometry(l) }
+
+// String returns WKT/EWKT geometry representation
+func (l *MultiLineString) String() string { return sridPrefix(l) + l.wkt() }
+
+// wkt returns WKT geometry representation
+func (l *MultiLineString) wkt() string {
+	s := "MULTILINESTRING" + wktDims(l)
+
 	if l.Len() == 0 {
 		s += " EMPTY"
 		return s
@@ -72,35 +87,47 @@ func (l *MultiLineString) Scan(src interface{}) error {
 
 // Value implements sql driver.Valuer interface
 func (l *MultiLineString) Value() (driver.Value, error) {
-	return l.String(), nil
+	return geometryValue(l, DefaultValueFormat)
 }
 
 // UnmarshalBinary implements encoding.BinaryUnmarshaler interface
 func (l *MultiLineString) UnmarshalBinary(data []byte) error {
-	h, byteOrder, offset := readHeader(data)
-	if h.Type() != MultiLineType {
-		return errors.New("not expected geometry type")
+	g, err := unmarshal(data, MultiLineType, DecodeOptions{})
+	if err != nil {
+		return err
 	}
 
-	l.header = h
-
-	var err error
-	l.ml, _, err = readMultiLine(
-		data[offset:],
-		byteOrder,
-		getReadPointFunc(h.wkbType),
-	)
-	return err
+	*l = *g.(*MultiLineString)
+	return nil
 }
 
 // MarshalBinary implements encoding.BinaryMarshaler interface
 func (l *MultiLineString) MarshalBinary() ([]byte, error) {
-	size := headerSize(l.HasSRID()) + multiLineSize(l, l.HasZ(), l.HasM())
-	b := make([]byte, size)
+	return l.appendBinary(nil, EncodeOptions{})
+}
+
+// AppendBinary appends binary representation of geometry to dst
+// and returns extended slice. Implements encoding.BinaryAppender interface
+func (l *MultiLineString) AppendBinary(dst []byte) ([]byte, error) {
+	return l.appendBinary(dst, EncodeOptions{})
+}
+
+// EncodedSize returns size of binary representation of geometry
+func (l *MultiLineString) EncodedSize() int {
+	return l.encodedSize(EncodeOptions{})
+}
+
+func (l *MultiLineString) encodedSize(opts EncodeOptions) int {
+	return headerSize(l.HasSRID() && !opts.ISO) + multiLineSize(l, l.HasZ(), l.HasM())
+}
+
+func (l *MultiLineString) appendBinary(dst []byte, opts EncodeOptions) ([]byte, error) {
+	hasSRID := l.HasSRID() && !opts.ISO
+	dst, b := grow(dst, l.encodedSize(opts))
 
 	byteOrder := getBinaryByteOrder(l.ByteOrder())
-	offset := writeHeader(l, l.Type(), byteOrder, l.HasSRID(), b)
-	writeMultiLine(l, byteOrder, l.HasZ(), l.HasM(), b[offset:])
+	offset := writeHeader(l, l.Type(), byteOrder, hasSRID, opts, b)
+	writeMultiLine(l, byteOrder, l.HasZ(), l.HasM(), opts, b[offset:])
 
-	return b, nil
+	return dst, nil
 }
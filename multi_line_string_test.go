@@ -2,6 +2,8 @@ package ewkb
 
 import (
 	"bytes"
+	"encoding/hex"
+	"errors"
 	"testing"
 	"time"
 
@@ -328,6 +330,45 @@ func TestMultiLineString_UnmarshalBinary(t *testing.T) {
 	}
 }
 
+func TestMultiLineString_UnmarshalBinary_MemberDimensions(t *testing.T) {
+	cases := []struct {
+		name string
+		data string
+		err  error
+	}{
+		{
+			"Z member of XY",
+			"01050000000100000001020000800100000000000000000000f03f00000000000000400000000000000840",
+			ErrDimensionMismatch,
+		},
+		{
+			"XY member of M",
+			"01050000400100000001020000000100000000000000000000f03f0000000000000040",
+			ErrDimensionMismatch,
+		},
+		{
+			"ZM member of ZM",
+			"01050000c00100000001020000c000000000",
+			nil,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			data, _ := hex.DecodeString(c.data)
+			var ml MultiLineString
+			err := ml.UnmarshalBinary(data)
+			if !errors.Is(err, c.err) {
+				t.Errorf("Expected %v, got %v\n", c.err, err)
+			}
+
+			if _, err = NewView(data, DecodeOptions{}); !errors.Is(err, c.err) {
+				t.Errorf("Expected %v, got %v\n", c.err, err)
+			}
+		})
+	}
+}
+
 func TestMultiLineString_Scan(t *testing.T) {
 	cases := []struct {
 		name     string
@@ -739,6 +780,42 @@ func TestMultiLineString_MarshalBinary(t *testing.T) {
 	}
 }
 
+func TestMultiLineString_Marshal_ISO(t *testing.T) {
+	cases := []struct {
+		name     string
+		geom     string
+		expected []byte
+	}{
+		{
+			"multiline with Z dimension",
+			"MULTILINESTRING((1 2 3))",
+			[]byte{
+				1, 237, 3, 0, 0, 1, 0, 0, 0, 1, 234, 3, 0, 0, 1, 0, 0, 0,
+				0, 0, 0, 0, 0, 0, 240, 63, 0, 0, 0, 0, 0, 0, 0, 64,
+				0, 0, 0, 0, 0, 0, 8, 64,
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			g, err := ParseEWKT(c.geom)
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			b, err := Marshal(g, EncodeOptions{ISO: true})
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			if !bytes.Equal(b, c.expected) {
+				t.Errorf("Expected %v, got %v\n", c.expected, b)
+			}
+		})
+	}
+}
+
 func checkMultiLine(
 	mline *MultiLineString, ebase Base,
 	emline geo.MultiLine, t *testing.T,
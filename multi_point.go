@@ -2,8 +2,6 @@ package ewkb
 
 import (
 	"database/sql/driver"
-	"errors"
-	"fmt"
 
 	"github.com/kcasctiv/go-ewkb/geo"
 )
@@ -31,21 +29,42 @@ func NewMultiPoint(b Base, mp geo.MultiPoint) MultiPoint {
 	}
 }
 
+// NewEmptyMultiPoint returns new empty MultiPoint,
+// created from geometry base
+func NewEmptyMultiPoint(b Base) MultiPoint {
+	return NewMultiPoint(b, geo.NewMultiPoint(nil))
+}
+
 // Point returns point with specified index
 func (p *MultiPoint) Point(idx int) geo.Point { return p.mp.Point(idx) }
 
 // Len returns length of MultiPoint (count of points)
 func (p *MultiPoint) Len() int { return p.mp.Len() }
 
-// String returns WKT/EWKT geometry representation
-func (p *MultiPoint) String() string {
-	var s string
-	if p.HasSRID() {
-		s = fmt.Sprintf("SRID=%d;", p.srid)
+// IsEmpty checks if MultiPoint has no points or all its points are empty
+func (p *MultiPoint) IsEmpty() bool {
+	for idx := 0; idx < p.Len(); idx++ {
+		if !isEmptyPoint(p.Point(idx), p.HasZ(), p.HasM()) {
+			return false
+		}
 	}
-	s += "MULTIPOINT"
-	if !p.HasZ() && p.HasM() {
-		s += "M"
+
+	return true
+}
+
+// Validate checks that dimensions of all points
+// and members match dimensions of geometry
+func (p *MultiPoint) Validate() error { return validateGeometry(p) }
+
+// String returns WKT/EWKT geometry representation
+func (p *MultiPoint) String() string { return sridPrefix(p) + p.wkt() }
+
+// wkt returns WKT geometry representation
+func (p *MultiPoint) wkt() string {
+	s := "MULTIPOINT" + wktDims(p)
+
+	if p.Len() == 0 {
+		return s + " EMPTY"
 	}
 
 	return s + printMultiPoint(p, p.HasZ(), p.HasM())
@@ -58,38 +77,54 @@ func (p *MultiPoint) Scan(src interface{}) error {
 
 // Value implements sql driver.Valuer interface
 func (p *MultiPoint) Value() (driver.Value, error) {
-	return p.String(), nil
+	return geometryValue(p, DefaultValueFormat)
 }
 
 // UnmarshalBinary implements encoding.BinaryUnmarshaler interface
 func (p *MultiPoint) UnmarshalBinary(data []byte) error {
-	h, byteOrder, offset := readHeader(data)
-	if h.Type() != MultiPointType {
-		return errors.New("not expected geometry type")
+	g, err := unmarshal(data, MultiPointType, DecodeOptions{})
+	if err != nil {
+		return err
 	}
 
-	p.header = h
-
-	var err error
-	p.mp, _, err = readMultiPoint(data[offset:], byteOrder, getReadPointFunc(h.wkbType))
-	return err
+	*p = *g.(*MultiPoint)
+	return nil
 }
 
 // MarshalBinary implements encoding.BinaryMarshaler interface
 func (p *MultiPoint) MarshalBinary() ([]byte, error) {
-	size := headerSize(p.HasSRID()) + multiPointSize(p, p.HasZ(), p.HasM())
-	b := make([]byte, size)
+	return p.appendBinary(nil, EncodeOptions{})
+}
+
+// AppendBinary appends binary representation of geometry to dst
+// and returns extended slice. Implements encoding.BinaryAppender interface
+func (p *MultiPoint) AppendBinary(dst []byte) ([]byte, error) {
+	return p.appendBinary(dst, EncodeOptions{})
+}
+
+// EncodedSize returns size of binary representation of geometry
+func (p *MultiPoint) EncodedSize() int {
+	return p.encodedSize(EncodeOptions{})
+}
+
+func (p *MultiPoint) encodedSize(opts EncodeOptions) int {
+	return headerSize(p.HasSRID() && !opts.ISO) + pointMembersSize(p, p.HasZ(), p.HasM())
+}
+
+func (p *MultiPoint) appendBinary(dst []byte, opts EncodeOptions) ([]byte, error) {
+	hasSRID := p.HasSRID() && !opts.ISO
+	dst, b := grow(dst, p.encodedSize(opts))
 
 	byteOrder := getBinaryByteOrder(p.ByteOrder())
-	offset := writeHeader(p, p.Type(), byteOrder, p.HasSRID(), b)
-	writeMultiPoint(p, byteOrder, p.HasZ(), p.HasM(), b[offset:])
+	offset := writeHeader(p, p.Type(), byteOrder, hasSRID, opts, b)
+	writePointMembers(p, byteOrder, opts, b[offset:])
 
-	return b, nil
+	return dst, nil
 }
 
 func printMultiPoint(p geo.MultiPoint, hasZ, hasM bool) string {
 	if p.Len() == 0 {
-		return " EMPTY"
+		return "EMPTY"
 	}
 
 	var s string
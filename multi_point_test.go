@@ -2,6 +2,8 @@ package ewkb
 
 import (
 	"bytes"
+	"encoding/hex"
+	"errors"
 	"testing"
 	"time"
 
@@ -138,9 +140,10 @@ func TestMultiPoint_UnmarshalBinary(t *testing.T) {
 		{
 			"simple",
 			[]byte{
-				1, 4, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-				240, 63, 0, 0, 0, 0, 0, 0, 8, 64, 0, 0, 0,
-				0, 0, 0, 0, 64, 0, 0, 0, 0, 0, 0, 16, 64,
+				1, 4, 0, 0, 0, 2, 0, 0, 0, 1, 1, 0, 0, 0,
+				0, 0, 0, 0, 0, 0, 240, 63, 0, 0, 0, 0, 0, 0,
+				8, 64, 1, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+				64, 0, 0, 0, 0, 0, 0, 16, 64,
 			},
 			true,
 			NewMultiPoint(
@@ -154,10 +157,11 @@ func TestMultiPoint_UnmarshalBinary(t *testing.T) {
 		{
 			"with Z dimension",
 			[]byte{
-				1, 4, 0, 0, 128, 2, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-				240, 63, 0, 0, 0, 0, 0, 0, 8, 64, 0, 0, 0, 0, 0,
-				0, 28, 64, 0, 0, 0, 0, 0, 0, 0, 64, 0, 0, 0,
-				0, 0, 0, 16, 64, 0, 0, 0, 0, 0, 0, 20, 64,
+				1, 4, 0, 0, 128, 2, 0, 0, 0, 1, 1, 0, 0, 128,
+				0, 0, 0, 0, 0, 0, 240, 63, 0, 0, 0, 0, 0, 0,
+				8, 64, 0, 0, 0, 0, 0, 0, 28, 64, 1, 1, 0, 0,
+				128, 0, 0, 0, 0, 0, 0, 0, 64, 0, 0, 0, 0, 0,
+				0, 16, 64, 0, 0, 0, 0, 0, 0, 20, 64,
 			},
 			true,
 			NewMultiPoint(
@@ -171,10 +175,11 @@ func TestMultiPoint_UnmarshalBinary(t *testing.T) {
 		{
 			"with M dimension",
 			[]byte{
-				1, 4, 0, 0, 64, 2, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-				240, 63, 0, 0, 0, 0, 0, 0, 8, 64, 0, 0, 0, 0, 0,
-				0, 28, 64, 0, 0, 0, 0, 0, 0, 0, 64, 0, 0, 0,
-				0, 0, 0, 16, 64, 0, 0, 0, 0, 0, 0, 20, 64,
+				1, 4, 0, 0, 64, 2, 0, 0, 0, 1, 1, 0, 0, 64,
+				0, 0, 0, 0, 0, 0, 240, 63, 0, 0, 0, 0, 0, 0,
+				8, 64, 0, 0, 0, 0, 0, 0, 28, 64, 1, 1, 0, 0,
+				64, 0, 0, 0, 0, 0, 0, 0, 64, 0, 0, 0, 0, 0,
+				0, 16, 64, 0, 0, 0, 0, 0, 0, 20, 64,
 			},
 			true,
 			NewMultiPoint(
@@ -188,11 +193,12 @@ func TestMultiPoint_UnmarshalBinary(t *testing.T) {
 		{
 			"with Z and M dimension",
 			[]byte{
-				1, 4, 0, 0, 192, 2, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-				240, 63, 0, 0, 0, 0, 0, 0, 8, 64, 0, 0, 0, 0,
-				0, 0, 28, 64, 0, 0, 0, 0, 0, 0, 0, 64, 0, 0, 0,
-				0, 0, 0, 0, 64, 0, 0, 0, 0, 0, 0, 16, 64, 0, 0,
-				0, 0, 0, 0, 20, 64, 0, 0, 0, 0, 0, 0, 0, 0,
+				1, 4, 0, 0, 192, 2, 0, 0, 0, 1, 1, 0, 0, 192,
+				0, 0, 0, 0, 0, 0, 240, 63, 0, 0, 0, 0, 0, 0,
+				8, 64, 0, 0, 0, 0, 0, 0, 28, 64, 0, 0, 0, 0,
+				0, 0, 0, 64, 1, 1, 0, 0, 192, 0, 0, 0, 0, 0,
+				0, 0, 64, 0, 0, 0, 0, 0, 0, 16, 64, 0, 0, 0,
+				0, 0, 0, 20, 64, 0, 0, 0, 0, 0, 0, 0, 0,
 			},
 			true,
 			NewMultiPoint(
@@ -206,10 +212,10 @@ func TestMultiPoint_UnmarshalBinary(t *testing.T) {
 		{
 			"with SRID",
 			[]byte{
-				1, 4, 0, 0, 32, 230, 16, 0, 0, 2,
-				0, 0, 0, 0, 0, 0, 0, 0, 0, 240, 63, 0,
-				0, 0, 0, 0, 0, 8, 64, 0, 0, 0, 0, 0,
-				0, 0, 64, 0, 0, 0, 0, 0, 0, 16, 64,
+				1, 4, 0, 0, 32, 230, 16, 0, 0, 2, 0, 0, 0, 1,
+				1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 240, 63, 0, 0,
+				0, 0, 0, 0, 8, 64, 1, 1, 0, 0, 0, 0, 0, 0,
+				0, 0, 0, 0, 64, 0, 0, 0, 0, 0, 0, 16, 64,
 			},
 			true,
 			NewMultiPoint(
@@ -237,10 +243,11 @@ func TestMultiPoint_UnmarshalBinary(t *testing.T) {
 		{
 			"with Z dimension corrupted",
 			[]byte{
-				1, 4, 0, 0, 128, 2, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-				240, 63, 0, 0, 0, 0, 0, 0, 8, 64, 0, 0, 0, 0, 0,
-				0, 28, 64, 0, 0, 0, 0, 0, 0, 0, 64, 0, 0,
-				0, 0, 0, 16, 64, 0, 0, 0, 0, 0, 0, 20, 64,
+				1, 4, 0, 0, 128, 2, 0, 0, 0, 1, 1, 0, 0, 128,
+				0, 0, 0, 0, 0, 0, 240, 63, 0, 0, 0, 0, 0, 0,
+				8, 64, 0, 0, 0, 0, 0, 0, 28, 64, 1, 1, 0, 0,
+				128, 0, 0, 0, 0, 0, 0, 0, 64, 0, 0, 0, 0, 0,
+				16, 64, 0, 0, 0, 0, 0, 0, 20, 64,
 			},
 			false,
 			MultiPoint{},
@@ -248,10 +255,11 @@ func TestMultiPoint_UnmarshalBinary(t *testing.T) {
 		{
 			"with M dimension corrupted",
 			[]byte{
-				1, 4, 0, 0, 64, 2, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-				240, 63, 0, 0, 0, 0, 0, 0, 8, 64, 0, 0, 0, 0,
-				0, 28, 64, 0, 0, 0, 0, 0, 0, 0, 64, 0, 0, 0,
-				0, 0, 0, 16, 64, 0, 0, 0, 0, 0, 0, 20, 64,
+				1, 4, 0, 0, 64, 2, 0, 0, 0, 1, 1, 0, 0, 64,
+				0, 0, 0, 0, 0, 0, 240, 63, 0, 0, 0, 0, 0, 0,
+				8, 64, 0, 0, 0, 0, 0, 28, 64, 0, 1, 1, 0, 0,
+				64, 0, 0, 0, 0, 0, 0, 64, 0, 0, 0, 0, 0, 0,
+				16, 64, 0, 0, 0, 0, 0, 0, 20, 64,
 			},
 			false,
 			MultiPoint{},
@@ -259,11 +267,12 @@ func TestMultiPoint_UnmarshalBinary(t *testing.T) {
 		{
 			"with Z and M dimension corrupted",
 			[]byte{
-				1, 4, 0, 0, 192, 2, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-				240, 63, 0, 0, 0, 0, 0, 0, 8, 64, 0, 0, 0, 0,
-				0, 0, 28, 64, 0, 0, 0, 0, 0, 0, 0, 64, 0, 0,
-				0, 0, 0, 0, 64, 0, 0, 0, 0, 0, 0, 16, 0, 0,
-				0, 0, 0, 0, 20, 64, 0, 0, 0, 0, 0, 0, 0, 0,
+				1, 4, 0, 0, 192, 2, 0, 0, 0, 1, 1, 0, 0, 192,
+				0, 0, 0, 0, 0, 0, 240, 63, 0, 0, 0, 0, 0, 0,
+				8, 64, 0, 0, 0, 0, 0, 0, 28, 64, 0, 0, 0, 0,
+				0, 0, 0, 64, 1, 1, 0, 0, 192, 0, 0, 0, 0, 0,
+				0, 64, 0, 0, 0, 0, 0, 0, 16, 0, 0, 0, 0, 0,
+				0, 20, 64, 0, 0, 0, 0, 0, 0, 0, 0,
 			},
 			false,
 			MultiPoint{},
@@ -335,6 +344,126 @@ func TestMultiPoint_UnmarshalBinary(t *testing.T) {
 	}
 }
 
+func TestMultiPoint_UnmarshalBinary_MemberDimensions(t *testing.T) {
+	cases := []struct {
+		name string
+		data string
+		err  error
+	}{
+		{
+			"Z member of XY",
+			"0104000000010000000101000080000000000000f03f00000000000000400000000000000840",
+			ErrDimensionMismatch,
+		},
+		{
+			"XY member of Z",
+			"0104000080010000000101000000000000000000f03f0000000000000040",
+			ErrDimensionMismatch,
+		},
+		{
+			"M member of Z",
+			"0104000080010000000101000040000000000000f03f00000000000000400000000000000840",
+			ErrDimensionMismatch,
+		},
+		{
+			"Z member of Z",
+			"0104000080010000000101000080000000000000f03f00000000000000400000000000000840",
+			nil,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			data, _ := hex.DecodeString(c.data)
+			var mp MultiPoint
+			err := mp.UnmarshalBinary(data)
+			if !errors.Is(err, c.err) {
+				t.Errorf("Expected %v, got %v\n", c.err, err)
+			}
+
+			if _, err = NewView(data, DecodeOptions{}); !errors.Is(err, c.err) {
+				t.Errorf("Expected %v, got %v\n", c.err, err)
+			}
+		})
+	}
+}
+
+func TestMultiPoint_Unmarshal(t *testing.T) {
+	cases := []struct {
+		name     string
+		data     []byte
+		opts     DecodeOptions
+		valid    bool
+		expected string
+	}{
+		{
+			"multipoint",
+			[]byte{
+				1, 4, 0, 0, 0, 2, 0, 0, 0, 1, 1, 0, 0, 0,
+				0, 0, 0, 0, 0, 0, 240, 63, 0, 0, 0, 0, 0, 0,
+				8, 64, 1, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+				64, 0, 0, 0, 0, 0, 0, 16, 64,
+			},
+			DecodeOptions{},
+			true,
+			"MULTIPOINT(1 3,2 4)",
+		},
+		{
+			"multipoint with big endian member",
+			[]byte{
+				1, 4, 0, 0, 0, 2, 0, 0, 0, 1, 1, 0, 0, 0,
+				0, 0, 0, 0, 0, 0, 240, 63, 0, 0, 0, 0, 0, 0,
+				8, 64, 0, 0, 0, 0, 1, 64, 0, 0, 0, 0, 0, 0,
+				0, 64, 16, 0, 0, 0, 0, 0, 0,
+			},
+			DecodeOptions{},
+			true,
+			"MULTIPOINT(1 3,2 4)",
+		},
+		{
+			"multipoint in legacy layout",
+			[]byte{
+				1, 4, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+				240, 63, 0, 0, 0, 0, 0, 0, 8, 64, 0, 0, 0,
+				0, 0, 0, 0, 64, 0, 0, 0, 0, 0, 0, 16, 64,
+			},
+			DecodeOptions{LegacyMulti: true},
+			true,
+			"MULTIPOINT(1 3,2 4)",
+		},
+		{
+			"multipoint in legacy layout without option",
+			[]byte{
+				1, 4, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+				240, 63, 0, 0, 0, 0, 0, 0, 8, 64, 0, 0, 0,
+				0, 0, 0, 0, 64, 0, 0, 0, 0, 0, 0, 16, 64,
+			},
+			DecodeOptions{},
+			false,
+			"",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			g, err := Unmarshal(c.data, c.opts)
+			if err != nil && c.valid {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+			if err == nil && !c.valid {
+				t.Fatal("Expected: error, got: no errors\n")
+			}
+			if !c.valid {
+				return
+			}
+
+			if s := g.String(); s != c.expected {
+				t.Errorf("Expected %q, got %q\n", c.expected, s)
+			}
+		})
+	}
+}
+
 func TestMultiPoint_Scan(t *testing.T) {
 	cases := []struct {
 		name     string
@@ -345,9 +474,10 @@ func TestMultiPoint_Scan(t *testing.T) {
 		{
 			"binary",
 			[]byte{
-				1, 4, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-				240, 63, 0, 0, 0, 0, 0, 0, 8, 64, 0, 0, 0,
-				0, 0, 0, 0, 64, 0, 0, 0, 0, 0, 0, 16, 64,
+				1, 4, 0, 0, 0, 2, 0, 0, 0, 1, 1, 0, 0, 0,
+				0, 0, 0, 0, 0, 0, 240, 63, 0, 0, 0, 0, 0, 0,
+				8, 64, 1, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+				64, 0, 0, 0, 0, 0, 0, 16, 64,
 			},
 			true,
 			NewMultiPoint(
@@ -361,12 +491,13 @@ func TestMultiPoint_Scan(t *testing.T) {
 		{
 			"hex binary",
 			[]byte{
-				48, 49, 48, 52, 48, 48, 48, 48, 48, 48, 48, 50, 48,
-				48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48,
-				48, 48, 48, 48, 70, 48, 51, 70, 48, 48, 48, 48, 48,
-				48, 48, 48, 48, 48, 48, 48, 48, 56, 52, 48, 48, 48,
-				48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 52,
-				48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48,
+				48, 49, 48, 52, 48, 48, 48, 48, 48, 48, 48, 50, 48, 48,
+				48, 48, 48, 48, 48, 49, 48, 49, 48, 48, 48, 48, 48, 48,
+				48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 70, 48,
+				51, 70, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48,
+				48, 56, 52, 48, 48, 49, 48, 49, 48, 48, 48, 48, 48, 48,
+				48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48,
+				52, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48,
 				49, 48, 52, 48,
 			},
 			true,
@@ -380,7 +511,7 @@ func TestMultiPoint_Scan(t *testing.T) {
 		},
 		{
 			"hex string",
-			"010400000002000000000000000000F03F000000000000084000000000000000400000000000001040",
+			"0104000000020000000101000000000000000000F03F0000000000000840010100000000000000000000400000000000001040",
 			true,
 			NewMultiPoint(
 				NewBase(NDR, false, false, false, 0),
@@ -659,9 +790,10 @@ func TestMultiPoint_MarshalBinary(t *testing.T) {
 		{
 			"simple",
 			[]byte{
-				1, 4, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-				240, 63, 0, 0, 0, 0, 0, 0, 8, 64, 0, 0, 0,
-				0, 0, 0, 0, 64, 0, 0, 0, 0, 0, 0, 16, 64,
+				1, 4, 0, 0, 0, 2, 0, 0, 0, 1, 1, 0, 0, 0,
+				0, 0, 0, 0, 0, 0, 240, 63, 0, 0, 0, 0, 0, 0,
+				8, 64, 1, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+				64, 0, 0, 0, 0, 0, 0, 16, 64,
 			},
 			NewMultiPoint(
 				NewBase(NDR, false, false, false, 0),
@@ -674,10 +806,11 @@ func TestMultiPoint_MarshalBinary(t *testing.T) {
 		{
 			"with Z dimension",
 			[]byte{
-				1, 4, 0, 0, 128, 2, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-				240, 63, 0, 0, 0, 0, 0, 0, 8, 64, 0, 0, 0, 0, 0,
-				0, 28, 64, 0, 0, 0, 0, 0, 0, 0, 64, 0, 0, 0,
-				0, 0, 0, 16, 64, 0, 0, 0, 0, 0, 0, 20, 64,
+				1, 4, 0, 0, 128, 2, 0, 0, 0, 1, 1, 0, 0, 128,
+				0, 0, 0, 0, 0, 0, 240, 63, 0, 0, 0, 0, 0, 0,
+				8, 64, 0, 0, 0, 0, 0, 0, 28, 64, 1, 1, 0, 0,
+				128, 0, 0, 0, 0, 0, 0, 0, 64, 0, 0, 0, 0, 0,
+				0, 16, 64, 0, 0, 0, 0, 0, 0, 20, 64,
 			},
 			NewMultiPoint(
 				NewBase(NDR, true, false, false, 0),
@@ -690,10 +823,11 @@ func TestMultiPoint_MarshalBinary(t *testing.T) {
 		{
 			"with M dimension",
 			[]byte{
-				1, 4, 0, 0, 64, 2, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-				240, 63, 0, 0, 0, 0, 0, 0, 8, 64, 0, 0, 0, 0, 0,
-				0, 28, 64, 0, 0, 0, 0, 0, 0, 0, 64, 0, 0, 0,
-				0, 0, 0, 16, 64, 0, 0, 0, 0, 0, 0, 20, 64,
+				1, 4, 0, 0, 64, 2, 0, 0, 0, 1, 1, 0, 0, 64,
+				0, 0, 0, 0, 0, 0, 240, 63, 0, 0, 0, 0, 0, 0,
+				8, 64, 0, 0, 0, 0, 0, 0, 28, 64, 1, 1, 0, 0,
+				64, 0, 0, 0, 0, 0, 0, 0, 64, 0, 0, 0, 0, 0,
+				0, 16, 64, 0, 0, 0, 0, 0, 0, 20, 64,
 			},
 			NewMultiPoint(
 				NewBase(NDR, false, true, false, 0),
@@ -706,11 +840,12 @@ func TestMultiPoint_MarshalBinary(t *testing.T) {
 		{
 			"with Z and M dimension",
 			[]byte{
-				1, 4, 0, 0, 192, 2, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-				240, 63, 0, 0, 0, 0, 0, 0, 8, 64, 0, 0, 0, 0,
-				0, 0, 28, 64, 0, 0, 0, 0, 0, 0, 0, 64, 0, 0, 0,
-				0, 0, 0, 0, 64, 0, 0, 0, 0, 0, 0, 16, 64, 0, 0,
-				0, 0, 0, 0, 20, 64, 0, 0, 0, 0, 0, 0, 0, 0,
+				1, 4, 0, 0, 192, 2, 0, 0, 0, 1, 1, 0, 0, 192,
+				0, 0, 0, 0, 0, 0, 240, 63, 0, 0, 0, 0, 0, 0,
+				8, 64, 0, 0, 0, 0, 0, 0, 28, 64, 0, 0, 0, 0,
+				0, 0, 0, 64, 1, 1, 0, 0, 192, 0, 0, 0, 0, 0,
+				0, 0, 64, 0, 0, 0, 0, 0, 0, 16, 64, 0, 0, 0,
+				0, 0, 0, 20, 64, 0, 0, 0, 0, 0, 0, 0, 0,
 			},
 			NewMultiPoint(
 				NewBase(NDR, true, true, false, 0),
@@ -723,10 +858,10 @@ func TestMultiPoint_MarshalBinary(t *testing.T) {
 		{
 			"with SRID",
 			[]byte{
-				1, 4, 0, 0, 32, 230, 16, 0, 0, 2,
-				0, 0, 0, 0, 0, 0, 0, 0, 0, 240, 63, 0,
-				0, 0, 0, 0, 0, 8, 64, 0, 0, 0, 0, 0,
-				0, 0, 64, 0, 0, 0, 0, 0, 0, 16, 64,
+				1, 4, 0, 0, 32, 230, 16, 0, 0, 2, 0, 0, 0, 1,
+				1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 240, 63, 0, 0,
+				0, 0, 0, 0, 8, 64, 1, 1, 0, 0, 0, 0, 0, 0,
+				0, 0, 0, 0, 64, 0, 0, 0, 0, 0, 0, 16, 64,
 			},
 			NewMultiPoint(
 				NewBase(NDR, false, false, true, 4326),
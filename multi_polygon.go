@@ -2,8 +2,6 @@ package ewkb
 
 import (
 	"database/sql/driver"
-	"errors"
-	"fmt"
 
 	"github.com/kcasctiv/go-ewkb/geo"
 )
@@ -31,23 +29,40 @@ func NewMultiPolygon(b Base, mp geo.MultiPolygon) MultiPolygon {
 	}
 }
 
+// NewEmptyMultiPolygon returns new empty MultiPolygon,
+// created from geometry base
+func NewEmptyMultiPolygon(b Base) MultiPolygon {
+	return NewMultiPolygon(b, geo.NewMultiPolygon(nil))
+}
+
 // Polygon returns polygon with specified index
 func (p *MultiPolygon) Polygon(idx int) geo.Polygon { return p.mp.Polygon(idx) }
 
 // Len returns count of polygons
 func (p *MultiPolygon) Len() int { return p.mp.Len() }
 
-// String returns WKT/EWKT geometry representation
-func (p *MultiPolygon) String() string {
-	var s string
-	if p.HasSRID() {
-		s = fmt.Sprintf("SRID=%d;", p.srid)
-	}
-	s += "MULTIPOLYGON"
-	if !p.HasZ() && p.HasM() {
-		s += "M"
+// IsEmpty checks if MultiPolygon has no polygons or all its polygons are empty
+func (p *MultiPolygon) IsEmpty() bool {
+	for idx := 0; idx < p.Len(); idx++ {
+		if !isEmptyPolygon(p.Polygon(idx)) {
+			return false
+		}
 	}
 
+	return true
+}
+
+// Validate checks that dimensions of all points
+// and members match dimensions of geometry
+func (p *MultiPolygon) Validate() error { return validateGeometry(p) }
+
+// String returns WKT/EWKT geometry representation
+func (p *MultiPolygon) String() string { return sridPrefix(p) + p.wkt() }
+
+// wkt returns WKT geometry representation
+func (p *MultiPolygon) wkt() string {
+	s := "MULTIPOLYGON" + wktDims(p)
+
 	if p.Len() == 0 {
 		s += " EMPTY"
 		return s
@@ -72,35 +87,47 @@ func (p *MultiPolygon) Scan(src interface{}) error {
 
 // Value implements sql driver.Valuer interface
 func (p *MultiPolygon) Value() (driver.Value, error) {
-	return p.String(), nil
+	return geometryValue(p, DefaultValueFormat)
 }
 
 // UnmarshalBinary implements encoding.BinaryUnmarshaler interface
 func (p *MultiPolygon) UnmarshalBinary(data []byte) error {
-	h, byteOrder, offset := readHeader(data)
-	if h.Type() != MultiPolygonType {
-		return errors.New("not expected geometry type")
+	g, err := unmarshal(data, MultiPolygonType, DecodeOptions{})
+	if err != nil {
+		return err
 	}
 
-	p.header = h
-
-	var err error
-	p.mp, _, err = readMultiPolygon(
-		data[offset:],
-		byteOrder,
-		getReadPointFunc(h.wkbType),
-	)
-	return err
+	*p = *g.(*MultiPolygon)
+	return nil
 }
 
 // MarshalBinary implements encoding.BinaryMarshaler interface
 func (p *MultiPolygon) MarshalBinary() ([]byte, error) {
-	size := headerSize(p.HasSRID()) + multiPolygonSize(p, p.HasZ(), p.HasM())
-	b := make([]byte, size)
+	return p.appendBinary(nil, EncodeOptions{})
+}
+
+// AppendBinary appends binary representation of geometry to dst
+// and returns extended slice. Implements encoding.BinaryAppender interface
+func (p *MultiPolygon) AppendBinary(dst []byte) ([]byte, error) {
+	return p.appendBinary(dst, EncodeOptions{})
+}
+
+// EncodedSize returns size of binary representation of geometry
+func (p *MultiPolygon) EncodedSize() int {
+	return p.encodedSize(EncodeOptions{})
+}
+
+func (p *MultiPolygon) encodedSize(opts EncodeOptions) int {
+	return headerSize(p.HasSRID() && !opts.ISO) + multiPolygonSize(p, p.HasZ(), p.HasM())
+}
+
+func (p *MultiPolygon) appendBinary(dst []byte, opts EncodeOptions) ([]byte, error) {
+	hasSRID := p.HasSRID() && !opts.ISO
+	dst, b := grow(dst, p.encodedSize(opts))
 
 	byteOrder := getBinaryByteOrder(p.ByteOrder())
-	offset := writeHeader(p, p.Type(), byteOrder, p.HasSRID(), b)
-	writeMultiPolygon(p, byteOrder, p.HasZ(), p.HasM(), b[offset:])
+	offset := writeHeader(p, p.Type(), byteOrder, hasSRID, opts, b)
+	writeMultiPolygon(p, p, PolygonType, byteOrder, opts, b[offset:])
 
-	return b, nil
+	return dst, nil
 }
@@ -0,0 +1,126 @@
+package ewkb
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+func TestMultiPolygon_UnmarshalBinary_MemberDimensions(t *testing.T) {
+	cases := []struct {
+		name string
+		data string
+		err  error
+	}{
+		{"Z member of XY", "010600000001000000010300008000000000", ErrDimensionMismatch},
+		{"XY member of Z", "010600008001000000010300000000000000", ErrDimensionMismatch},
+		{"Z member of Z", "010600008001000000010300008000000000", nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			data, _ := hex.DecodeString(c.data)
+			var mp MultiPolygon
+			err := mp.UnmarshalBinary(data)
+			if !errors.Is(err, c.err) {
+				t.Errorf("Expected %v, got %v\n", c.err, err)
+			}
+
+			if _, err = NewView(data, DecodeOptions{}); !errors.Is(err, c.err) {
+				t.Errorf("Expected %v, got %v\n", c.err, err)
+			}
+		})
+	}
+}
+
+func TestMultiPolygon_Unmarshal(t *testing.T) {
+	cases := []struct {
+		name     string
+		data     []byte
+		opts     DecodeOptions
+		valid    bool
+		expected string
+	}{
+		{
+			"multipolygon",
+			[]byte{
+				1, 6, 0, 0, 0, 1, 0, 0, 0, 1, 3, 0, 0, 0,
+				1, 0, 0, 0, 4, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+				0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+				0, 0, 240, 63, 0, 0, 0, 0, 0, 0, 240, 63, 0, 0,
+				0, 0, 0, 0, 240, 63, 0, 0, 0, 0, 0, 0, 0, 0,
+				0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+				0, 0,
+			},
+			DecodeOptions{},
+			true,
+			"MULTIPOLYGON(((0 0,1 1,1 0,0 0)))",
+		},
+		{
+			"multipolygon in legacy layout",
+			[]byte{
+				1, 6, 0, 0, 0, 1, 0, 0, 0, 1, 0, 0, 0, 4,
+				0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+				0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 240, 63, 0,
+				0, 0, 0, 0, 0, 240, 63, 0, 0, 0, 0, 0, 0, 240,
+				63, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+				0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+			},
+			DecodeOptions{LegacyMulti: true},
+			true,
+			"MULTIPOLYGON(((0 0,1 1,1 0,0 0)))",
+		},
+		{
+			"multipolygon with not a polygon member",
+			[]byte{1, 6, 0, 0, 0, 1, 0, 0, 0, 1, 2, 0, 0, 0, 0, 0, 0, 0},
+			DecodeOptions{},
+			false,
+			"",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			g, err := Unmarshal(c.data, c.opts)
+			if err != nil && c.valid {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+			if err == nil && !c.valid {
+				t.Fatal("Expected: error, got: no errors\n")
+			}
+			if !c.valid {
+				return
+			}
+
+			if s := g.String(); s != c.expected {
+				t.Errorf("Expected %q, got %q\n", c.expected, s)
+			}
+		})
+	}
+}
+
+func TestMultiPolygon_MarshalBinary(t *testing.T) {
+	g, err := ParseEWKT("MULTIPOLYGON(((0 0,1 1,1 0,0 0)))")
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	b, err := g.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	expected := []byte{
+		1, 6, 0, 0, 0, 1, 0, 0, 0, 1, 3, 0, 0, 0,
+		1, 0, 0, 0, 4, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 240, 63, 0, 0, 0, 0, 0, 0, 240, 63, 0, 0,
+		0, 0, 0, 0, 240, 63, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0,
+	}
+	if !bytes.Equal(b, expected) {
+		t.Errorf("Expected %v, got %v\n", expected, b)
+	}
+}
@@ -0,0 +1,121 @@
+package ewkb
+
+import "database/sql/driver"
+
+// MultiSurface presents MultiSurface geometry object,
+// consisting of Polygon and CurvePolygon
+// geometry objects
+type MultiSurface struct {
+	header
+	surfaces []Geometry
+}
+
+// NewMultiSurface returns new MultiSurface,
+// created from geometry base and surfaces.
+// Surfaces must match base, as members of GeometryCollection
+func NewMultiSurface(b Base, surfaces []Geometry) MultiSurface {
+	return MultiSurface{
+		header: header{
+			byteOrder: b.ByteOrder(),
+			wkbType: getFlags(
+				b.HasZ(),
+				b.HasM(),
+				b.HasSRID(),
+			) | MultiSurfaceType,
+			srid: b.SRID(),
+		},
+		surfaces: surfaces,
+	}
+}
+
+// NewEmptyMultiSurface returns new empty MultiSurface,
+// created from geometry base
+func NewEmptyMultiSurface(b Base) MultiSurface {
+	return NewMultiSurface(b, nil)
+}
+
+// Surface returns surface with specified index
+func (p *MultiSurface) Surface(idx int) Geometry { return p.surfaces[idx] }
+
+// Len returns count of surfaces
+func (p *MultiSurface) Len() int { return len(p.surfaces) }
+
+// IsEmpty checks if MultiSurface has no surfaces or all its surfaces are empty
+func (p *MultiSurface) IsEmpty() bool { return isEmptyGeometries(p.surfaces) }
+
+// Validate checks that dimensions of all points
+// and members match dimensions of geometry
+func (p *MultiSurface) Validate() error { return validateGeometry(p) }
+
+// String returns WKT/EWKT geometry representation
+func (p *MultiSurface) String() string { return sridPrefix(p) + p.wkt() }
+
+// wkt returns WKT geometry representation
+func (p *MultiSurface) wkt() string {
+	return "MULTISURFACE" + wktDims(p) + printMembers(p.surfaces, PolygonType)
+}
+
+// Scan implements sql.Scanner interface
+func (p *MultiSurface) Scan(src interface{}) error {
+	return scanGeometry(src, p)
+}
+
+// Value implements sql driver.Valuer interface
+func (p *MultiSurface) Value() (driver.Value, error) {
+	return geometryValue(p, DefaultValueFormat)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler interface
+func (p *MultiSurface) UnmarshalBinary(data []byte) error {
+	g, err := unmarshal(data, MultiSurfaceType, DecodeOptions{})
+	if err != nil {
+		return err
+	}
+
+	*p = *g.(*MultiSurface)
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler interface
+func (p *MultiSurface) MarshalBinary() ([]byte, error) {
+	return p.appendBinary(nil, EncodeOptions{})
+}
+
+// AppendBinary appends binary representation of geometry to dst
+// and returns extended slice. Implements encoding.BinaryAppender interface
+func (p *MultiSurface) AppendBinary(dst []byte) ([]byte, error) {
+	return p.appendBinary(dst, EncodeOptions{})
+}
+
+// EncodedSize returns size of binary representation of geometry
+func (p *MultiSurface) EncodedSize() int {
+	return p.encodedSize(EncodeOptions{})
+}
+
+func (p *MultiSurface) encodedSize(opts EncodeOptions) int {
+	// members of unsupported types are rejected by encoding
+	size, _ := collectionSize(p.surfaces)
+	return headerSize(p.HasSRID() && !opts.ISO) + size
+}
+
+func (p *MultiSurface) appendBinary(dst []byte, opts EncodeOptions) ([]byte, error) {
+	if err := validateMembers(p); err != nil {
+		return dst, err
+	}
+
+	size, err := collectionSize(p.surfaces)
+	if err != nil {
+		return dst, err
+	}
+
+	hasSRID := p.HasSRID() && !opts.ISO
+	out, b := grow(dst, headerSize(hasSRID)+size)
+
+	byteOrder := getBinaryByteOrder(p.ByteOrder())
+	offset := writeHeader(p, p.Type(), byteOrder, hasSRID, opts, b)
+	if _, err = writeCollection(p.surfaces, byteOrder, opts, b[offset:]); err != nil {
+		return dst, err
+	}
+
+	return out, nil
+}
@@ -0,0 +1,132 @@
+package ewkb
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// geometryPtr is constraint of pointer to geometry object of type T
+type geometryPtr[T any] interface {
+	*T
+	Geometry
+}
+
+// Null presents nullable geometry object of concrete type T,
+// like sql.Null does for other types. Unlike Wrapper,
+// it rejects geometry of other type with ErrTypeMismatch.
+// Aliases, like NullPoint, are available for all types
+type Null[T any, P geometryPtr[T]] struct {
+	Geometry T
+	// Valid is true if geometry is not NULL
+	Valid bool
+}
+
+// Aliases of nullable geometry objects
+type (
+	NullPoint              = Null[Point, *Point]
+	NullLineString         = Null[LineString, *LineString]
+	NullPolygon            = Null[Polygon, *Polygon]
+	NullMultiPoint         = Null[MultiPoint, *MultiPoint]
+	NullMultiLineString    = Null[MultiLineString, *MultiLineString]
+	NullMultiPolygon       = Null[MultiPolygon, *MultiPolygon]
+	NullGeometryCollection = Null[GeometryCollection, *GeometryCollection]
+	NullCircularString     = Null[CircularString, *CircularString]
+	NullCompoundCurve      = Null[CompoundCurve, *CompoundCurve]
+	NullCurvePolygon       = Null[CurvePolygon, *CurvePolygon]
+	NullMultiCurve         = Null[MultiCurve, *MultiCurve]
+	NullMultiSurface       = Null[MultiSurface, *MultiSurface]
+	NullPolyhedralSurface  = Null[PolyhedralSurface, *PolyhedralSurface]
+	NullTin                = Null[Tin, *Tin]
+	NullTriangle           = Null[Triangle, *Triangle]
+)
+
+// Scan implements sql.Scanner interface
+func (n *Null[T, P]) Scan(src interface{}) error {
+	if src == nil {
+		*n = Null[T, P]{}
+		return nil
+	}
+
+	if err := P(&n.Geometry).Scan(src); err != nil {
+		*n = Null[T, P]{}
+		return err
+	}
+
+	n.Valid = true
+	return nil
+}
+
+// Value implements sql driver.Valuer interface
+func (n Null[T, P]) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+
+	return P(&n.Geometry).Value()
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler interface.
+// Nil data is unmarshaled as NULL
+func (n *Null[T, P]) UnmarshalBinary(data []byte) error {
+	if data == nil {
+		*n = Null[T, P]{}
+		return nil
+	}
+
+	if err := P(&n.Geometry).UnmarshalBinary(data); err != nil {
+		*n = Null[T, P]{}
+		return err
+	}
+
+	n.Valid = true
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler interface.
+// NULL is marshaled as nil
+func (n Null[T, P]) MarshalBinary() ([]byte, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+
+	return P(&n.Geometry).MarshalBinary()
+}
+
+// MarshalJSON implements json.Marshaler interface.
+// NULL is marshaled as JSON null
+func (n Null[T, P]) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+
+	m, ok := interface{}(P(&n.Geometry)).(json.Marshaler)
+	if !ok {
+		return nil, fmt.Errorf("%w %T", ErrUnsupportedGeoJSON, n.Geometry)
+	}
+
+	return m.MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+// JSON null is unmarshaled as NULL
+func (n *Null[T, P]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*n = Null[T, P]{}
+		return nil
+	}
+
+	u, ok := interface{}(P(&n.Geometry)).(json.Unmarshaler)
+	if !ok {
+		return fmt.Errorf("%w %T", ErrUnsupportedGeoJSON, n.Geometry)
+	}
+
+	if err := u.UnmarshalJSON(data); err != nil {
+		*n = Null[T, P]{}
+		return err
+	}
+
+	n.Valid = true
+	return nil
+}
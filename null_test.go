@@ -0,0 +1,148 @@
+package ewkb
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNull_Scan(t *testing.T) {
+	var n NullPolygon
+	if err := n.Scan("SRID=4326;POLYGON((0 0,1 1,1 0,0 0))"); err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	if !n.Valid {
+		t.Errorf("Valid: expected %v, got %v\n", true, n.Valid)
+	}
+
+	if s := n.Geometry.String(); s != "SRID=4326;POLYGON((0 0,1 1,1 0,0 0))" {
+		t.Errorf("Expected %q, got %q\n", "SRID=4326;POLYGON((0 0,1 1,1 0,0 0))", s)
+	}
+
+	if err := n.Scan(nil); err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	if n.Valid {
+		t.Errorf("Valid: expected %v, got %v\n", false, n.Valid)
+	}
+}
+
+func TestNull_Scan_TypeMismatch(t *testing.T) {
+	var n NullPolygon
+	err := n.Scan("0102000000020000000000000000000000000000000000000000000000000000000000f03f000000000000f03f")
+	if !errors.Is(err, ErrTypeMismatch) {
+		t.Fatalf("Expected %v, got %v\n", ErrTypeMismatch, err)
+	}
+
+	if !strings.Contains(err.Error(), "expected POLYGON, got LINESTRING") {
+		t.Errorf("Expected message with type names, got %q\n", err.Error())
+	}
+
+	if n.Valid {
+		t.Errorf("Valid: expected %v, got %v\n", false, n.Valid)
+	}
+}
+
+func TestNull_Value(t *testing.T) {
+	var n NullPoint
+	v, err := n.Value()
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	if v != nil {
+		t.Errorf("Expected %v, got %v\n", nil, v)
+	}
+
+	if err = n.Scan("POINT(1 2)"); err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	if v, err = n.Value(); err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	if v != "POINT(1 2)" {
+		t.Errorf("Expected %v, got %v\n", "POINT(1 2)", v)
+	}
+}
+
+func TestNull_Binary(t *testing.T) {
+	var n NullLineString
+	data, err := n.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	if data != nil {
+		t.Errorf("Expected %v, got %v\n", nil, data)
+	}
+
+	if err = n.Scan("LINESTRING(1 2,3 4)"); err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	if data, err = n.MarshalBinary(); err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	var n2 NullLineString
+	if err = n2.UnmarshalBinary(data); err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	if !n2.Valid || n2.Geometry.String() != "LINESTRING(1 2,3 4)" {
+		t.Errorf("Expected %v, got %v\n", "LINESTRING(1 2,3 4)", n2.Geometry.String())
+	}
+
+	var p NullPoint
+	if err = p.UnmarshalBinary(data); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("Expected %v, got %v\n", ErrTypeMismatch, err)
+	}
+}
+
+func TestNull_JSON(t *testing.T) {
+	var s struct {
+		A NullPoint `json:"a"`
+		B NullPoint `json:"b"`
+	}
+
+	data := `{"a":{"type":"Point","coordinates":[1,2]},"b":null}`
+	if err := json.Unmarshal([]byte(data), &s); err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	if !s.A.Valid || s.A.Geometry.X() != 1 || s.A.Geometry.Y() != 2 {
+		t.Errorf("Expected %v, got %v\n", "POINT(1 2)", s.A.Geometry.String())
+	}
+
+	if s.B.Valid {
+		t.Errorf("Valid: expected %v, got %v\n", false, s.B.Valid)
+	}
+
+	out, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	if string(out) != data {
+		t.Errorf("Expected %s, got %s\n", data, out)
+	}
+
+	var l NullLineString
+	if err = json.Unmarshal([]byte(`{"type":"Point","coordinates":[1,2]}`), &l); err == nil {
+		t.Error("Expected: error, got: no errors\n")
+	}
+
+	var c NullCircularString
+	if err = c.Scan("CIRCULARSTRING(0 0,1 1,2 0)"); err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	if _, err = json.Marshal(c); err == nil {
+		t.Error("Expected: error, got: no errors\n")
+	}
+}
@@ -0,0 +1,251 @@
+// Package pgxcodec contains pgx codec for PostGIS
+// geometry and geography types, which transfers
+// geometry objects in binary format.
+//
+// Package is a separate module, so that the root module
+// does not depend on pgx. It requires Go 1.25.0 or later,
+// as pgx v5.10 does
+package pgxcodec
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kcasctiv/go-ewkb"
+)
+
+// Codec presents pgtype.Codec of geometry and geography types.
+// In binary format values are transferred as EWKB,
+// in text format as hex encoded EWKB.
+// It encodes ewkb.Geometry and ewkb.Wrapper values
+// and scans into *ewkb.Wrapper, *ewkb.Geometry and pointers
+// to geometry objects of concrete types
+type Codec struct {
+	// Options are used for decoding of geometry
+	Options ewkb.DecodeOptions
+}
+
+// Register discovers OIDs of geometry and geography types,
+// which are created by PostGIS extension, and registers codec c
+// for them and their arrays in type map of connection.
+// If c is nil, Codec with default options is registered.
+// It is intended to be called from AfterConnect hook of pool
+func Register(ctx context.Context, conn *pgx.Conn, c *Codec) error {
+	if c == nil {
+		c = &Codec{}
+	}
+
+	rows, err := conn.Query(ctx,
+		"select typname, oid, typarray from pg_type where typname in ('geometry', 'geography')")
+	if err != nil {
+		return fmt.Errorf("pgxcodec: could not discover types: %w", err)
+	}
+
+	var name string
+	var oid, arrayOID uint32
+	found := false
+	_, err = pgx.ForEachRow(rows, []any{&name, &oid, &arrayOID}, func() error {
+		RegisterType(conn.TypeMap(), c, name, oid, arrayOID)
+		found = found || name == "geometry"
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("pgxcodec: could not discover types: %w", err)
+	}
+
+	if !found {
+		return fmt.Errorf("pgxcodec: geometry type not found, PostGIS extension is not installed")
+	}
+
+	return nil
+}
+
+// RegisterType registers codec for type with specified name and OID
+// and for its array type with arrayOID, if it is not zero, in type map.
+// Geometry objects are encoded as values of registered type by default
+func RegisterType(m *pgtype.Map, c *Codec, name string, oid, arrayOID uint32) {
+	t := &pgtype.Type{Name: name, OID: oid, Codec: c}
+	m.RegisterType(t)
+	if arrayOID != 0 {
+		m.RegisterType(&pgtype.Type{
+			Name:  "_" + name,
+			OID:   arrayOID,
+			Codec: &pgtype.ArrayCodec{ElementType: t},
+		})
+	}
+
+	if name != "geometry" {
+		return
+	}
+
+	m.RegisterDefaultPgType(ewkb.Wrapper{}, name)
+	for _, g := range defaultGeoms {
+		m.RegisterDefaultPgType(g, name)
+	}
+}
+
+// defaultGeoms holds geometry objects of every type,
+// which are encoded as geometry by default
+var defaultGeoms = []ewkb.Geometry{
+	&ewkb.Point{},
+	&ewkb.LineString{},
+	&ewkb.Polygon{},
+	&ewkb.MultiPoint{},
+	&ewkb.MultiLineString{},
+	&ewkb.MultiPolygon{},
+	&ewkb.GeometryCollection{},
+	&ewkb.CircularString{},
+	&ewkb.CompoundCurve{},
+	&ewkb.CurvePolygon{},
+	&ewkb.MultiCurve{},
+	&ewkb.MultiSurface{},
+	&ewkb.PolyhedralSurface{},
+	&ewkb.Tin{},
+	&ewkb.Triangle{},
+}
+
+// FormatSupported implements pgtype.Codec interface
+func (c *Codec) FormatSupported(format int16) bool {
+	return format == pgtype.BinaryFormatCode || format == pgtype.TextFormatCode
+}
+
+// PreferredFormat implements pgtype.Codec interface
+func (c *Codec) PreferredFormat() int16 { return pgtype.BinaryFormatCode }
+
+// PlanEncode implements pgtype.Codec interface
+func (c *Codec) PlanEncode(m *pgtype.Map, oid uint32, format int16, value any) pgtype.EncodePlan {
+	switch value.(type) {
+	case ewkb.Geometry, ewkb.Wrapper, *ewkb.Wrapper:
+		return encodePlan{text: format == pgtype.TextFormatCode}
+	default:
+		return nil
+	}
+}
+
+// PlanScan implements pgtype.Codec interface
+func (c *Codec) PlanScan(m *pgtype.Map, oid uint32, format int16, target any) pgtype.ScanPlan {
+	if !c.FormatSupported(format) {
+		return nil
+	}
+
+	switch target.(type) {
+	case *ewkb.Wrapper, *ewkb.Geometry, ewkb.Geometry:
+		return scanPlan{text: format == pgtype.TextFormatCode, opts: c.Options}
+	default:
+		return nil
+	}
+}
+
+// DecodeDatabaseSQLValue implements pgtype.Codec interface.
+// Returned value can be scanned by geometry objects
+// and ewkb.Wrapper
+func (c *Codec) DecodeDatabaseSQLValue(
+	m *pgtype.Map, oid uint32, format int16, src []byte,
+) (driver.Value, error) {
+	if src == nil {
+		return nil, nil
+	}
+
+	if format == pgtype.TextFormatCode {
+		return string(src), nil
+	}
+
+	return append([]byte(nil), src...), nil
+}
+
+// DecodeValue implements pgtype.Codec interface.
+// Returns ewkb.Geometry, or nil for NULL
+func (c *Codec) DecodeValue(m *pgtype.Map, oid uint32, format int16, src []byte) (any, error) {
+	if src == nil {
+		return nil, nil
+	}
+
+	var g ewkb.Geometry
+	err := scanPlan{text: format == pgtype.TextFormatCode, opts: c.Options}.Scan(src, &g)
+	return g, err
+}
+
+type encodePlan struct {
+	text bool
+}
+
+func (p encodePlan) Encode(value any, buf []byte) ([]byte, error) {
+	var g ewkb.Geometry
+	switch v := value.(type) {
+	case ewkb.Geometry:
+		g = v
+	case ewkb.Wrapper:
+		g = v.Geometry
+	case *ewkb.Wrapper:
+		g = v.Geometry
+	}
+
+	if g == nil {
+		return nil, nil
+	}
+
+	if !p.text {
+		return g.AppendBinary(buf)
+	}
+
+	data, err := g.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	return hex.AppendEncode(buf, data), nil
+}
+
+type scanPlan struct {
+	text bool
+	opts ewkb.DecodeOptions
+}
+
+func (p scanPlan) Scan(src []byte, target any) error {
+	if src == nil {
+		switch t := target.(type) {
+		case *ewkb.Wrapper:
+			t.Geometry = nil
+			return nil
+		case *ewkb.Geometry:
+			*t = nil
+			return nil
+		default:
+			return fmt.Errorf("pgxcodec: cannot scan NULL into %T", target)
+		}
+	}
+
+	if p.text {
+		data := make([]byte, hex.DecodedLen(len(src)))
+		if _, err := hex.Decode(data, src); err != nil {
+			return fmt.Errorf("pgxcodec: could not decode hex: %w", err)
+		}
+		src = data
+	}
+
+	g, err := ewkb.Unmarshal(src, p.opts)
+	if err != nil {
+		return err
+	}
+
+	switch t := target.(type) {
+	case *ewkb.Wrapper:
+		t.Geometry = g
+	case *ewkb.Geometry:
+		*t = g
+	default:
+		// Pointer to geometry object of concrete type
+		dst, val := reflect.ValueOf(target).Elem(), reflect.ValueOf(g).Elem()
+		if dst.Type() != val.Type() {
+			return fmt.Errorf("pgxcodec: cannot scan %T into %T: %w", g, target, ewkb.ErrTypeMismatch)
+		}
+		dst.Set(val)
+	}
+
+	return nil
+}
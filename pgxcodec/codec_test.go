@@ -0,0 +1,172 @@
+package pgxcodec
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kcasctiv/go-ewkb"
+)
+
+// OIDs of geometry types. PostGIS extension
+// assigns them, when it is created in database
+const (
+	geometryOID      = 16392
+	geometryArrayOID = 16401
+)
+
+// Wire bytes of values in formats, used by PostGIS
+var (
+	// select 'SRID=4326;POINT(1 2)'::geometry
+	pointBinary = "0101000020e6100000000000000000f03f0000000000000040"
+	pointText   = "0101000020E6100000000000000000F03F0000000000000040"
+	// select array['POINT(1 2)'::geometry, null, 'LINESTRING(0 0,1 1)']
+	arrayBinary = "00000001000000010000400800000003000000010000001501010000" +
+		"00000000000000f03f0000000000000040ffffffff00000029010200" +
+		"00000200000000000000000000000000000000000000000000000000" +
+		"f03f000000000000f03f"
+)
+
+func newMap() *pgtype.Map {
+	m := pgtype.NewMap()
+	RegisterType(m, &Codec{}, "geometry", geometryOID, geometryArrayOID)
+	return m
+}
+
+func TestCodec_Scan(t *testing.T) {
+	m := newMap()
+	cases := []struct {
+		name   string
+		format int16
+		src    string
+	}{
+		{"binary", pgtype.BinaryFormatCode, pointBinary},
+		{"text", pgtype.TextFormatCode, pointText},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			src := []byte(c.src)
+			if c.format == pgtype.BinaryFormatCode {
+				src, _ = hex.DecodeString(c.src)
+			}
+
+			var w ewkb.Wrapper
+			if err := m.Scan(geometryOID, c.format, src, &w); err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+			if s := w.Geometry.String(); s != "SRID=4326;POINT(1 2)" {
+				t.Errorf("Expected %q, got %q\n", "SRID=4326;POINT(1 2)", s)
+			}
+
+			var p ewkb.Point
+			if err := m.Scan(geometryOID, c.format, src, &p); err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+			if p.X() != 1 || p.Y() != 2 || p.SRID() != 4326 {
+				t.Errorf("Expected %v, got %v\n", "SRID=4326;POINT(1 2)", p.String())
+			}
+
+			var g ewkb.Geometry
+			if err := m.Scan(geometryOID, c.format, src, &g); err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+			if g.Type() != ewkb.PointType {
+				t.Errorf("Expected %v, got %v\n", ewkb.PointType, g.Type())
+			}
+		})
+	}
+}
+
+func TestCodec_Scan_Null(t *testing.T) {
+	m := newMap()
+	w := ewkb.Wrapper{Geometry: &ewkb.Point{}}
+	if err := m.Scan(geometryOID, pgtype.BinaryFormatCode, nil, &w); err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+	if w.Geometry != nil {
+		t.Errorf("Expected %v, got %v\n", nil, w.Geometry)
+	}
+
+	var p ewkb.Point
+	if err := m.Scan(geometryOID, pgtype.BinaryFormatCode, nil, &p); err == nil {
+		t.Error("Expected: error, got: no errors\n")
+	}
+}
+
+func TestCodec_Scan_TypeMismatch(t *testing.T) {
+	src, _ := hex.DecodeString(pointBinary)
+	var l ewkb.LineString
+	err := newMap().Scan(geometryOID, pgtype.BinaryFormatCode, src, &l)
+	if !errors.Is(err, ewkb.ErrTypeMismatch) {
+		t.Errorf("Expected %v, got %v\n", ewkb.ErrTypeMismatch, err)
+	}
+}
+
+func TestCodec_Encode(t *testing.T) {
+	m := newMap()
+	g, err := ewkb.ParseEWKT("SRID=4326;POINT(1 2)")
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	expected, _ := hex.DecodeString(pointBinary)
+	for _, value := range []any{g, ewkb.Wrapper{Geometry: g}, &ewkb.Wrapper{Geometry: g}} {
+		buf, err := m.Encode(geometryOID, pgtype.BinaryFormatCode, value, nil)
+		if err != nil {
+			t.Fatalf("Expected: no errors, got error: %v\n", err)
+		}
+		if !bytes.Equal(buf, expected) {
+			t.Errorf("Expected %x, got %x\n", expected, buf)
+		}
+	}
+
+	buf, err := m.Encode(geometryOID, pgtype.TextFormatCode, g, nil)
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+	if !bytes.EqualFold(buf, []byte(pointText)) {
+		t.Errorf("Expected %s, got %s\n", pointText, buf)
+	}
+
+	buf, err = m.Encode(geometryOID, pgtype.BinaryFormatCode, ewkb.Wrapper{}, nil)
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+	if buf != nil {
+		t.Errorf("Expected %v, got %v\n", nil, buf)
+	}
+}
+
+func TestCodec_Array(t *testing.T) {
+	m := newMap()
+	src, _ := hex.DecodeString(arrayBinary)
+
+	var ws []ewkb.Wrapper
+	if err := m.Scan(geometryArrayOID, pgtype.BinaryFormatCode, src, &ws); err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	if len(ws) != 3 {
+		t.Fatalf("Expected %v, got %v\n", 3, len(ws))
+	}
+	if s := ws[0].Geometry.String(); s != "POINT(1 2)" {
+		t.Errorf("Expected %q, got %q\n", "POINT(1 2)", s)
+	}
+	if ws[1].Geometry != nil {
+		t.Errorf("Expected %v, got %v\n", nil, ws[1].Geometry)
+	}
+	if s := ws[2].Geometry.String(); s != "LINESTRING(0 0,1 1)" {
+		t.Errorf("Expected %q, got %q\n", "LINESTRING(0 0,1 1)", s)
+	}
+
+	buf, err := m.Encode(geometryArrayOID, pgtype.BinaryFormatCode, ws, nil)
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+	if !bytes.Equal(buf, src) {
+		t.Errorf("Expected %x, got %x\n", src, buf)
+	}
+}
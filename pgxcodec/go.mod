@@ -0,0 +1,14 @@
+module github.com/kcasctiv/go-ewkb/pgxcodec
+
+go 1.25.0
+
+require (
+	github.com/jackc/pgx/v5 v5.10.0
+	github.com/kcasctiv/go-ewkb v0.0.0-20261016223918-a14d6e741254
+)
+
+require (
+	github.com/jackc/pgpassfile v1.0.0 // indirect
+	github.com/jackc/pgservicefile v0.0.0-20240606120523-5a60cdf6a761 // indirect
+	golang.org/x/text v0.29.0 // indirect
+)
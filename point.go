@@ -2,8 +2,6 @@ package ewkb
 
 import (
 	"database/sql/driver"
-	"errors"
-	"fmt"
 	"math"
 	"strconv"
 
@@ -33,6 +31,21 @@ func NewPoint(b Base, p geo.Point) Point {
 	}
 }
 
+// NewEmptyPoint returns new empty point,
+// created from geometry base. All coordinates
+// of empty point are NaN, as PostGIS encodes them
+func NewEmptyPoint(b Base) Point {
+	dims := coordDims{known: true, z: b.HasZ(), m: b.HasM()}
+	return NewPoint(b, dims.emptyPoint())
+}
+
+// IsEmpty checks if all coordinates of point are NaN
+func (p *Point) IsEmpty() bool { return isEmptyPoint(p.point, p.HasZ(), p.HasM()) }
+
+// Validate checks that dimensions of coordinates
+// match dimensions of point
+func (p *Point) Validate() error { return validateGeometry(p) }
+
 // X returns value of X dimension
 func (p *Point) X() float64 { return p.point.X() }
 
@@ -45,18 +58,15 @@ func (p *Point) Z() float64 { return p.point.Z() }
 // M returns value of M dimension
 func (p *Point) M() float64 { return p.point.M() }
 
+// Layout returns dimensions of point, specified by its base
+func (p *Point) Layout() geo.Layout { return geo.NewLayout(p.HasZ(), p.HasM()) }
+
 // String returns WKT/EWKT geometry representation
-func (p *Point) String() string {
-	var s string
-	if p.HasSRID() {
-		s = fmt.Sprintf("SRID=%d;", p.srid)
-	}
-	s += "POINT"
-	if !p.HasZ() && p.HasM() {
-		s += "M"
-	}
+func (p *Point) String() string { return sridPrefix(p) + p.wkt() }
 
-	return s + printPoint(p, p.HasZ(), p.HasM(), true)
+// wkt returns WKT geometry representation
+func (p *Point) wkt() string {
+	return "POINT" + wktDims(p) + printPoint(p, p.HasZ(), p.HasM(), true)
 }
 
 // Scan implements sql.Scanner interface
@@ -66,39 +76,57 @@ func (p *Point) Scan(src interface{}) error {
 
 // Value implements sql driver.Valuer interface
 func (p *Point) Value() (driver.Value, error) {
-	return p.String(), nil
+	return geometryValue(p, DefaultValueFormat)
 }
 
 // UnmarshalBinary implements encoding.BinaryUnmarshaler interface
 func (p *Point) UnmarshalBinary(data []byte) error {
-	h, byteOrder, offset := readHeader(data)
-	if h.Type() != PointType {
-		return errors.New("not expected geometry type")
+	g, err := unmarshal(data, PointType, DecodeOptions{})
+	if err != nil {
+		return err
 	}
 
-	p.header = h
-
-	var err error
-	p.point, _, err = getReadPointFunc(h.wkbType)(data[offset:], byteOrder)
-	return err
+	*p = *g.(*Point)
+	return nil
 }
 
 // MarshalBinary implements encoding.BinaryMarshaler interface
 func (p *Point) MarshalBinary() ([]byte, error) {
-	size := headerSize(p.HasSRID()) + pointSize(p.HasZ(), p.HasM())
-	b := make([]byte, size)
+	return p.appendBinary(nil, EncodeOptions{})
+}
+
+// AppendBinary appends binary representation of geometry to dst
+// and returns extended slice. Implements encoding.BinaryAppender interface
+func (p *Point) AppendBinary(dst []byte) ([]byte, error) {
+	return p.appendBinary(dst, EncodeOptions{})
+}
+
+// EncodedSize returns size of binary representation of geometry
+func (p *Point) EncodedSize() int {
+	return p.encodedSize(EncodeOptions{})
+}
+
+func (p *Point) encodedSize(opts EncodeOptions) int {
+	return headerSize(p.HasSRID() && !opts.ISO) + pointSize(p.HasZ(), p.HasM())
+}
+
+func (p *Point) appendBinary(dst []byte, opts EncodeOptions) ([]byte, error) {
+	hasSRID := p.HasSRID() && !opts.ISO
+	dst, b := grow(dst, p.encodedSize(opts))
 
 	byteOrder := getBinaryByteOrder(p.ByteOrder())
-	offset := writeHeader(p, p.Type(), byteOrder, p.HasSRID(), b)
+	offset := writeHeader(p, p.Type(), byteOrder, hasSRID, opts, b)
 	writePoint(p, byteOrder, p.HasZ(), p.HasM(), b[offset:])
 
-	return b, nil
+	return dst, nil
 }
 
 func printPoint(p geo.Point, hasZ, hasM, brackets bool) string {
-	if math.IsNaN(p.X()) || math.IsNaN(p.Y()) ||
-		math.IsNaN(p.Z()) || math.IsNaN(p.M()) {
-		return " EMPTY"
+	if isEmptyPoint(p, hasZ, hasM) {
+		if brackets {
+			return " EMPTY"
+		}
+		return "EMPTY"
 	}
 
 	s := strconv.FormatFloat(p.X(), 'f', -1, 64) +
@@ -116,3 +144,10 @@ func printPoint(p geo.Point, hasZ, hasM, brackets bool) string {
 	}
 	return s
 }
+
+// isEmptyPoint checks if all coordinates of point
+// with specified dimensions are NaN
+func isEmptyPoint(p geo.Point, hasZ, hasM bool) bool {
+	return math.IsNaN(p.X()) && math.IsNaN(p.Y()) &&
+		(!hasZ || math.IsNaN(p.Z())) && (!hasM || math.IsNaN(p.M()))
+}
@@ -2,6 +2,7 @@ package ewkb
 
 import (
 	"bytes"
+	"errors"
 	"math"
 	"testing"
 	"time"
@@ -219,6 +220,24 @@ func TestPoint_UnmarshalBinary(t *testing.T) {
 	}
 }
 
+func TestPoint_UnmarshalBinary_TypeMismatch(t *testing.T) {
+	var p Point
+	err := p.UnmarshalBinary([]byte{1, 2, 0, 0, 0, 0, 0, 0, 0})
+
+	var te *TypeMismatchError
+	if !errors.As(err, &te) {
+		t.Fatalf("Expected *TypeMismatchError, got %v\n", err)
+	}
+
+	if te.Expected != PointType || te.Actual != LineType {
+		t.Errorf("Expected %v and %v, got %v and %v\n", PointType, LineType, te.Expected, te.Actual)
+	}
+
+	if !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("Expected %v, got %v\n", ErrTypeMismatch, err)
+	}
+}
+
 func TestPoint_Scan(t *testing.T) {
 	cases := []struct {
 		name     string
@@ -489,3 +508,51 @@ func TestPoint_MarshalBinary(t *testing.T) {
 		})
 	}
 }
+
+func TestPoint_Marshal_ISO(t *testing.T) {
+	cases := []struct {
+		name     string
+		geom     string
+		expected []byte
+	}{
+		{
+			"point",
+			"POINT(7 8)",
+			[]byte{1, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 28, 64, 0, 0, 0, 0, 0, 0, 32, 64},
+		},
+		{
+			"point with Z dimension",
+			"POINT(1 2 3)",
+			[]byte{
+				1, 233, 3, 0, 0, 0, 0, 0, 0, 0, 0, 240, 63, 0, 0,
+				0, 0, 0, 0, 0, 64, 0, 0, 0, 0, 0, 0, 8, 64,
+			},
+		},
+		{
+			"point with M dimension and SRID",
+			"SRID=4326;POINTM(1 2 3)",
+			[]byte{
+				1, 209, 7, 0, 0, 0, 0, 0, 0, 0, 0, 240, 63, 0, 0,
+				0, 0, 0, 0, 0, 64, 0, 0, 0, 0, 0, 0, 8, 64,
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			g, err := ParseEWKT(c.geom)
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			b, err := Marshal(g, EncodeOptions{ISO: true})
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			if !bytes.Equal(b, c.expected) {
+				t.Errorf("Expected %v, got %v\n", c.expected, b)
+			}
+		})
+	}
+}
@@ -2,8 +2,6 @@ package ewkb
 
 import (
 	"database/sql/driver"
-	"errors"
-	"fmt"
 
 	"github.com/kcasctiv/go-ewkb/geo"
 )
@@ -31,21 +29,34 @@ func NewPolygon(b Base, poly geo.Polygon) Polygon {
 	}
 }
 
+// NewEmptyPolygon returns new empty Polygon,
+// created from geometry base
+func NewEmptyPolygon(b Base) Polygon {
+	return NewPolygon(b, geo.NewPolygon(nil))
+}
+
 // Ring returns ring with specified index
 func (p *Polygon) Ring(idx int) geo.MultiPoint { return p.poly.Ring(idx) }
 
 // Len returns count of rings
 func (p *Polygon) Len() int { return p.poly.Len() }
 
+// IsEmpty checks if Polygon has no rings or its exterior ring has no points
+func (p *Polygon) IsEmpty() bool { return isEmptyPolygon(p) }
+
+// Validate checks that dimensions of all points
+// and members match dimensions of geometry
+func (p *Polygon) Validate() error { return validateGeometry(p) }
+
 // String returns WKT/EWKT geometry representation
-func (p *Polygon) String() string {
-	var s string
-	if p.HasSRID() {
-		s = fmt.Sprintf("SRID=%d;", p.srid)
-	}
-	s += "POLYGON"
-	if !p.HasZ() && p.HasM() {
-		s += "M"
+func (p *Polygon) String() string { return sridPrefix(p) + p.wkt() }
+
+// wkt returns WKT geometry representation
+func (p *Polygon) wkt() string {
+	s := "POLYGON" + wktDims(p)
+
+	if p.Len() == 0 {
+		return s + " EMPTY"
 	}
 
 	return s + printPolygon(p, p.HasZ(), p.HasM())
@@ -58,38 +69,54 @@ func (p *Polygon) Scan(src interface{}) error {
 
 // Value implements sql driver.Valuer interface
 func (p *Polygon) Value() (driver.Value, error) {
-	return p.String(), nil
+	return geometryValue(p, DefaultValueFormat)
 }
 
 // UnmarshalBinary implements encoding.BinaryUnmarshaler interface
 func (p *Polygon) UnmarshalBinary(data []byte) error {
-	h, byteOrder, offset := readHeader(data)
-	if h.Type() != PolygonType {
-		return errors.New("not expected geometry type")
+	g, err := unmarshal(data, PolygonType, DecodeOptions{})
+	if err != nil {
+		return err
 	}
 
-	p.header = h
-
-	var err error
-	p.poly, _, err = readPolygon(data[offset:], byteOrder, getReadPointFunc(h.wkbType))
-	return err
+	*p = *g.(*Polygon)
+	return nil
 }
 
 // MarshalBinary implements encoding.BinaryMarshaler interface
 func (p *Polygon) MarshalBinary() ([]byte, error) {
-	size := headerSize(p.HasSRID()) + polygonSize(p, p.HasZ(), p.HasM())
-	b := make([]byte, size)
+	return p.appendBinary(nil, EncodeOptions{})
+}
+
+// AppendBinary appends binary representation of geometry to dst
+// and returns extended slice. Implements encoding.BinaryAppender interface
+func (p *Polygon) AppendBinary(dst []byte) ([]byte, error) {
+	return p.appendBinary(dst, EncodeOptions{})
+}
+
+// EncodedSize returns size of binary representation of geometry
+func (p *Polygon) EncodedSize() int {
+	return p.encodedSize(EncodeOptions{})
+}
+
+func (p *Polygon) encodedSize(opts EncodeOptions) int {
+	return headerSize(p.HasSRID() && !opts.ISO) + polygonSize(p, p.HasZ(), p.HasM())
+}
+
+func (p *Polygon) appendBinary(dst []byte, opts EncodeOptions) ([]byte, error) {
+	hasSRID := p.HasSRID() && !opts.ISO
+	dst, b := grow(dst, p.encodedSize(opts))
 
 	byteOrder := getBinaryByteOrder(p.ByteOrder())
-	offset := writeHeader(p, p.Type(), byteOrder, p.HasSRID(), b)
+	offset := writeHeader(p, p.Type(), byteOrder, hasSRID, opts, b)
 	writePolygon(p, byteOrder, p.HasZ(), p.HasM(), b[offset:])
 
-	return b, nil
+	return dst, nil
 }
 
 func printPolygon(p geo.Polygon, hasZ, hasM bool) string {
 	if p.Len() == 0 {
-		return " EMPTY"
+		return "EMPTY"
 	}
 
 	var s string
@@ -99,3 +126,9 @@ func printPolygon(p geo.Polygon, hasZ, hasM bool) string {
 
 	return "(" + s[:len(s)-1] + ")"
 }
+
+// isEmptyPolygon checks if polygon has no rings
+// or its exterior ring has no points
+func isEmptyPolygon(p geo.Polygon) bool {
+	return p.Len() == 0 || p.Ring(0).Len() == 0
+}
@@ -0,0 +1,135 @@
+package ewkb
+
+import (
+	"database/sql/driver"
+
+	"github.com/kcasctiv/go-ewkb/geo"
+)
+
+// PolyhedralSurface presents PolyhedralSurface geometry object,
+// which is surface, consisting of polygons,
+// sharing common edges
+type PolyhedralSurface struct {
+	header
+	mp geo.MultiPolygon
+}
+
+// NewPolyhedralSurface returns new PolyhedralSurface,
+// created from geometry base and coords data
+func NewPolyhedralSurface(b Base, mp geo.MultiPolygon) PolyhedralSurface {
+	return PolyhedralSurface{
+		header: header{
+			byteOrder: b.ByteOrder(),
+			wkbType: getFlags(
+				b.HasZ(),
+				b.HasM(),
+				b.HasSRID(),
+			) | PolyhedralSurfaceType,
+			srid: b.SRID(),
+		},
+		mp: mp,
+	}
+}
+
+// NewEmptyPolyhedralSurface returns new empty PolyhedralSurface,
+// created from geometry base
+func NewEmptyPolyhedralSurface(b Base) PolyhedralSurface {
+	return NewPolyhedralSurface(b, geo.NewMultiPolygon(nil))
+}
+
+// Polygon returns polygon with specified index
+func (p *PolyhedralSurface) Polygon(idx int) geo.Polygon { return p.mp.Polygon(idx) }
+
+// Len returns count of polygons
+func (p *PolyhedralSurface) Len() int { return p.mp.Len() }
+
+// IsEmpty checks if PolyhedralSurface has no polygons or all its polygons are empty
+func (p *PolyhedralSurface) IsEmpty() bool {
+	for idx := 0; idx < p.Len(); idx++ {
+		if !isEmptyPolygon(p.Polygon(idx)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Validate checks that dimensions of all points
+// and members match dimensions of geometry
+func (p *PolyhedralSurface) Validate() error { return validateGeometry(p) }
+
+// String returns WKT/EWKT geometry representation
+func (p *PolyhedralSurface) String() string { return sridPrefix(p) + p.wkt() }
+
+// wkt returns WKT geometry representation
+func (p *PolyhedralSurface) wkt() string {
+	s := "POLYHEDRALSURFACE" + wktDims(p)
+
+	if p.Len() == 0 {
+		s += " EMPTY"
+		return s
+	}
+
+	s += "("
+	if p.Len() > 0 {
+		for idx := 0; idx < p.Len(); idx++ {
+			s += printPolygon(p.Polygon(idx), p.HasZ(), p.HasM()) + ","
+		}
+
+		s = s[:len(s)-1]
+	}
+
+	return s + ")"
+}
+
+// Scan implements sql.Scanner interface
+func (p *PolyhedralSurface) Scan(src interface{}) error {
+	return scanGeometry(src, p)
+}
+
+// Value implements sql driver.Valuer interface
+func (p *PolyhedralSurface) Value() (driver.Value, error) {
+	return geometryValue(p, DefaultValueFormat)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler interface
+func (p *PolyhedralSurface) UnmarshalBinary(data []byte) error {
+	g, err := unmarshal(data, PolyhedralSurfaceType, DecodeOptions{})
+	if err != nil {
+		return err
+	}
+
+	*p = *g.(*PolyhedralSurface)
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler interface
+func (p *PolyhedralSurface) MarshalBinary() ([]byte, error) {
+	return p.appendBinary(nil, EncodeOptions{})
+}
+
+// AppendBinary appends binary representation of geometry to dst
+// and returns extended slice. Implements encoding.BinaryAppender interface
+func (p *PolyhedralSurface) AppendBinary(dst []byte) ([]byte, error) {
+	return p.appendBinary(dst, EncodeOptions{})
+}
+
+// EncodedSize returns size of binary representation of geometry
+func (p *PolyhedralSurface) EncodedSize() int {
+	return p.encodedSize(EncodeOptions{})
+}
+
+func (p *PolyhedralSurface) encodedSize(opts EncodeOptions) int {
+	return headerSize(p.HasSRID() && !opts.ISO) + multiPolygonSize(p, p.HasZ(), p.HasM())
+}
+
+func (p *PolyhedralSurface) appendBinary(dst []byte, opts EncodeOptions) ([]byte, error) {
+	hasSRID := p.HasSRID() && !opts.ISO
+	dst, b := grow(dst, p.encodedSize(opts))
+
+	byteOrder := getBinaryByteOrder(p.ByteOrder())
+	offset := writeHeader(p, p.Type(), byteOrder, hasSRID, opts, b)
+	writeMultiPolygon(p, p, PolygonType, byteOrder, opts, b[offset:])
+
+	return dst, nil
+}
@@ -0,0 +1,177 @@
+package ewkb
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/kcasctiv/go-ewkb/geo"
+)
+
+var polyhedralGeoms = []string{
+	"TRIANGLE((0 0,0 1,1 0,0 0))",
+	"SRID=4326;TRIANGLE EMPTY",
+	"TRIANGLE((0 0 1,0 1 1,1 0 1,0 0 1))",
+	"TIN(((0 0 0,0 0 1,0 1 0,0 0 0)),((0 0 0,0 1 0,1 1 0,0 0 0)))",
+	"TINM(((0 0 1,0 1 2,1 0 3,0 0 1)))",
+	"TIN EMPTY",
+	"POLYHEDRALSURFACE(((0 0 0,0 1 0,1 1 0,1 0 0,0 0 0)),((0 0 0,0 0 1,0 1 1,0 1 0,0 0 0)))",
+	"SRID=3857;POLYHEDRALSURFACE EMPTY",
+	"GEOMETRYCOLLECTION(TRIANGLE((0 0,0 1,1 0,0 0)),POINT(1 2))",
+}
+
+func TestPolyhedral_String(t *testing.T) {
+	for _, s := range polyhedralGeoms {
+		t.Run(s, func(t *testing.T) {
+			g, err := ParseEWKT(s)
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			if gs := g.String(); gs != s {
+				t.Errorf("Expected %q, got %q\n", s, gs)
+			}
+		})
+	}
+}
+
+func TestPolyhedral_Binary(t *testing.T) {
+	for _, s := range polyhedralGeoms {
+		t.Run(s, func(t *testing.T) {
+			g, err := ParseEWKT(s)
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			data, err := g.MarshalBinary()
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			if size := g.EncodedSize(); size != len(data) {
+				t.Errorf("EncodedSize: expected %v, got %v\n", len(data), size)
+			}
+
+			var w Wrapper
+			if err = w.Scan(data); err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			if gs := w.Geometry.String(); gs != s {
+				t.Errorf("Expected %q, got %q\n", s, gs)
+			}
+
+			v, err := NewView(data, DecodeOptions{})
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			if v.Type() != g.Type() {
+				t.Errorf("Type: expected %v, got %v\n", g.Type(), v.Type())
+			}
+		})
+	}
+}
+
+func TestTin_View(t *testing.T) {
+	g, err := ParseEWKT("TIN(((0 0,0 1,1 0,0 0)),((1 1,1 2,2 1,1 1)))")
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	data, err := g.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	v, err := NewView(data, DecodeOptions{})
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	if v.Len() != 2 {
+		t.Fatalf("Len: expected %v, got %v\n", 2, v.Len())
+	}
+
+	part := v.Part(1)
+	if part.Type() != TriangleType {
+		t.Errorf("Type: expected %v, got %v\n", TriangleType, part.Type())
+	}
+
+	if x := part.Ring(0).Point(2).X(); x != 2 {
+		t.Errorf("Expected %v, got %v\n", 2, x)
+	}
+}
+
+func TestTriangle_Invalid(t *testing.T) {
+	invalid := []string{
+		"TRIANGLE((0 0,0 1,1 0))",
+		"TRIANGLE((0 0,0 1,1 0,1 1))",
+		"TRIANGLE((0 0,0 1,1 1,1 0,0 0))",
+		"TRIANGLE((0 0,0 1,1 0,0 0),(0 0,0 1,1 0,0 0))",
+		"TIN(((0 0,0 1,1 0,0 0)),((0 0,0 1,1 0)))",
+	}
+	for _, s := range invalid {
+		t.Run(s, func(t *testing.T) {
+			if _, err := ParseEWKT(s); err == nil {
+				t.Error("Expected: error, got: no errors\n")
+			}
+		})
+	}
+
+	ring := geo.NewMultiPoint([]geo.Point{
+		geo.NewPoint(0, 0), geo.NewPoint(0, 1), geo.NewPoint(1, 0),
+	})
+	tri := NewTriangle(NewBase(NDR, false, false, false, 0), geo.NewPolygon([]geo.MultiPoint{ring}))
+	if _, err := tri.MarshalBinary(); !errors.Is(err, ErrInvalidTriangle) {
+		t.Errorf("Expected %v, got %v\n", ErrInvalidTriangle, err)
+	}
+
+	tin := NewTin(NewBase(NDR, false, false, false, 0), geo.NewMultiPolygon([]geo.Polygon{tri.poly}))
+	if _, err := tin.MarshalBinary(); !errors.Is(err, ErrInvalidTriangle) {
+		t.Errorf("Expected %v, got %v\n", ErrInvalidTriangle, err)
+	}
+
+	// Triangle with three points
+	data := []byte{
+		1, 17, 0, 0, 0, 1, 0, 0, 0, 3, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 240, 63,
+		0, 0, 0, 0, 0, 0, 240, 63, 0, 0, 0, 0, 0, 0, 0, 0,
+	}
+
+	var d *DecodeError
+	if _, err := Unmarshal(data, DecodeOptions{}); !errors.Is(err, ErrInvalidTriangle) || !errors.As(err, &d) {
+		t.Errorf("Expected %v, got %v\n", ErrInvalidTriangle, err)
+	}
+	if _, err := NewView(data, DecodeOptions{}); !errors.Is(err, ErrInvalidTriangle) {
+		t.Errorf("Expected %v, got %v\n", ErrInvalidTriangle, err)
+	}
+}
+
+func TestPolyhedral_UnmarshalBinary_MemberDimensions(t *testing.T) {
+	cases := []struct {
+		name string
+		data string
+		err  error
+	}{
+		{"Z polygon of XY surface", "010f00000001000000010300008000000000", ErrDimensionMismatch},
+		{"XY polygon of XY surface", "010f00000001000000010300000000000000", nil},
+		{"Z triangle of XY tin", "011000000001000000011100008000000000", ErrDimensionMismatch},
+		{"M triangle of M tin", "011000004001000000011100004000000000", nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			data, _ := hex.DecodeString(c.data)
+			_, err := Unmarshal(data, DecodeOptions{})
+			if !errors.Is(err, c.err) {
+				t.Errorf("Expected %v, got %v\n", c.err, err)
+			}
+
+			if _, err = NewView(data, DecodeOptions{}); !errors.Is(err, c.err) {
+				t.Errorf("Expected %v, got %v\n", c.err, err)
+			}
+		})
+	}
+}
@@ -2,28 +2,84 @@ package ewkb
 
 import (
 	"encoding/binary"
-	"errors"
 	"math"
 
 	"github.com/kcasctiv/go-ewkb/geo"
 )
 
-func readHeader(data []byte) (header, binary.ByteOrder, int) {
+func readHeader(data []byte) (header, binary.ByteOrder, int, error) {
+	if len(data) < headerSize(false) {
+		return header{}, nil, 0, ErrTruncated
+	}
+
+	if data[0] != XDR && data[0] != NDR {
+		return header{}, nil, 0, ErrInvalidByteOrder
+	}
+
 	byteOrder := getBinaryByteOrder(data[0])
 	offset := 1
 
-	wkbType := byteOrder.Uint32(data[offset:])
+	wkbType := normalizeType(byteOrder.Uint32(data[offset:]))
 	var h header
 	h.byteOrder = data[0]
 	h.wkbType = wkbType
 	offset += 4
 
 	if (wkbType & sridFlag) == sridFlag {
+		if len(data) < headerSize(true) {
+			return header{}, nil, 0, ErrTruncated
+		}
+
 		h.srid = int32(byteOrder.Uint32(data[offset:]))
 		offset += 4
 	}
 
-	return h, byteOrder, offset
+	return h, byteOrder, offset, nil
+}
+
+// readCount reads count of elements. Count, which exceeds
+// maximum of signed 32-bit integer, is invalid.
+// Count is not checked against remaining data,
+// so it must be bounded by capCount for allocations
+func readCount(b []byte, byteOrder binary.ByteOrder) (int, error) {
+	if len(b) < 4 {
+		return 0, ErrTruncated
+	}
+
+	count := uint64(byteOrder.Uint32(b))
+	if count > math.MaxInt32 {
+		return 0, ErrInvalidCount
+	}
+
+	return int(count), nil
+}
+
+// capCount bounds count of elements, every one of which takes
+// at least minSize bytes, by count of elements, which can fit into b.
+// Elements are read in loop, which reports the real truncation point,
+// so only allocations, made for elements, need to be bounded
+func capCount(count int, b []byte, minSize int) int {
+	return min(count, len(b)/minSize)
+}
+
+// normalizeType converts ISO WKB type code
+// into EWKB type with dimension flags.
+// EWKB types are returned as is
+func normalizeType(wkbType uint32) uint32 {
+	flags := wkbType & (zFlag | mFlag | sridFlag)
+	code := wkbType &^ flags
+	switch code / 1000 {
+	case 1:
+		flags |= zFlag
+	case 2:
+		flags |= mFlag
+	case 3:
+		flags |= zFlag | mFlag
+	default:
+		return wkbType
+	}
+
+	return flags | code%1000
 }
 
 type readPointFunc func(b []byte, byteOrder binary.ByteOrder) (geo.Point, int, error)
@@ -44,7 +100,7 @@ func getReadPointFunc(wkbType uint32) readPointFunc {
 
 func readPoint(b []byte, byteOrder binary.ByteOrder) (geo.Point, int, error) {
 	if len(b) < 16 {
-		return nil, 0, errors.New("out of range")
+		return nil, 0, ErrTruncated
 	}
 
 	x := math.Float64frombits(byteOrder.Uint64(b))
@@ -54,7 +110,7 @@ func readPoint(b []byte, byteOrder binary.ByteOrder) (geo.Point, int, error) {
 
 func readPointZ(b []byte, byteOrder binary.ByteOrder) (geo.Point, int, error) {
 	if len(b) < 24 {
-		return nil, 0, errors.New("out of range")
+		return nil, 0, ErrTruncated
 	}
 
 	x := math.Float64frombits(byteOrder.Uint64(b))
@@ -65,7 +121,7 @@ func readPointZ(b []byte, byteOrder binary.ByteOrder) (geo.Point, int, error) {
 
 func readPointM(b []byte, byteOrder binary.ByteOrder) (geo.Point, int, error) {
 	if len(b) < 24 {
-		return nil, 0, errors.New("out of range")
+		return nil, 0, ErrTruncated
 	}
 
 	x := math.Float64frombits(byteOrder.Uint64(b))
@@ -76,7 +132,7 @@ func readPointM(b []byte, byteOrder binary.ByteOrder) (geo.Point, int, error) {
 
 func readPointZM(b []byte, byteOrder binary.ByteOrder) (geo.Point, int, error) {
 	if len(b) < 32 {
-		return nil, 0, errors.New("out of range")
+		return nil, 0, ErrTruncated
 	}
 
 	x := math.Float64frombits(byteOrder.Uint64(b))
@@ -86,152 +142,566 @@ func readPointZM(b []byte, byteOrder binary.ByteOrder) (geo.Point, int, error) {
 	return geo.NewPointZM(x, y, z, m), 32, nil
 }
 
-func readMultiPoint(
-	b []byte, byteOrder binary.ByteOrder,
-	readFunc readPointFunc) (geo.MultiPoint, int, error) {
-	if len(b) < 4 {
-		return nil, 0, errors.New("out of range")
+// reader holds options, consumed resources
+// and current location of decoding of single geometry
+type reader struct {
+	opts   DecodeOptions
+	data   []byte
+	path   []pathElem
+	points int
+	rings  int
+	parts  int
+}
+
+// error returns DecodeError, located at offset off of b,
+// which must be the tail of data being decoded
+func (r *reader) error(b []byte, off int, err error) error {
+	return &DecodeError{
+		Err:    err,
+		Offset: len(r.data) - len(b) + off,
+		Path:   formatPath(r.path),
 	}
+}
 
-	mplen := int(byteOrder.Uint32(b))
-	points := make([]geo.Point, mplen)
-	var err error
+// enter starts reading of nested elements with specified name
+func (r *reader) enter(name string) { r.path = append(r.path, pathElem{name: name}) }
+
+// at sets index of currently read nested element
+func (r *reader) at(idx int) { r.path[len(r.path)-1].idx = idx }
+
+// leave finishes reading of nested elements
+func (r *reader) leave() { r.path = r.path[:len(r.path)-1] }
+
+func (r *reader) addPoints(n int) error {
+	r.points += n
+	if r.opts.MaxPoints > 0 && r.points > r.opts.MaxPoints {
+		return ErrLimitExceeded
+	}
+
+	return nil
+}
+
+func (r *reader) addRings(n int) error {
+	r.rings += n
+	if r.opts.MaxRings > 0 && r.rings > r.opts.MaxRings {
+		return ErrLimitExceeded
+	}
+
+	return nil
+}
+
+func (r *reader) addParts(n int) error {
+	r.parts += n
+	if r.opts.MaxParts > 0 && r.parts > r.opts.MaxParts {
+		return ErrLimitExceeded
+	}
+
+	return nil
+}
+
+// readCount reads count of elements, checking limit with add func
+func (r *reader) readCount(b []byte, byteOrder binary.ByteOrder, add func(int) error) (int, error) {
+	count, err := readCount(b, byteOrder)
+	if err == nil {
+		err = add(count)
+	}
+	if err != nil {
+		return 0, r.error(b, 0, err)
+	}
+
+	return count, nil
+}
+
+// readHeader reads header of geometry,
+// which must be of type, supported by package
+func (r *reader) readHeader(b []byte) (header, binary.ByteOrder, int, error) {
+	h, byteOrder, n, err := readHeader(b)
+	if err != nil {
+		return h, nil, 0, r.error(b, 0, err)
+	}
+
+	if !isKnownType(h.Type()) {
+		return h, nil, 0, r.error(b, 0, ErrUnknownType)
+	}
+
+	return h, byteOrder, n, nil
+}
+
+// readMemberHeader reads header of member of multi geometry,
+// which must be of specified type
+func (r *reader) readMemberHeader(
+	b []byte, typ uint32,
+) (header, binary.ByteOrder, int, error) {
+	h, byteOrder, n, err := r.readHeader(b)
+	if err != nil {
+		return h, nil, 0, err
+	}
+
+	if h.Type() != typ {
+		return h, nil, 0, r.error(b, 0, &TypeMismatchError{Expected: typ, Actual: h.Type()})
+	}
+
+	return h, byteOrder, n, nil
+}
+
+func (r *reader) readPoint(
+	b []byte, byteOrder binary.ByteOrder, readFunc readPointFunc,
+) (geo.Point, int, error) {
+	p, n, err := readFunc(b, byteOrder)
+	if err != nil {
+		return nil, 0, r.error(b, 0, err)
+	}
+
+	return p, n, nil
+}
+
+// readMultiPoint reads points with dimensions
+// of wkbType into flat sequence
+func (r *reader) readMultiPoint(
+	b []byte, byteOrder binary.ByteOrder, wkbType uint32,
+) (geo.Sequence, int, error) {
+	hasZ, hasM := (wkbType&zFlag) == zFlag, (wkbType&mFlag) == mFlag
+	mplen, err := r.readCount(b, byteOrder, r.addPoints)
+	if err != nil {
+		return geo.Sequence{}, 0, err
+	}
+
+	flat, n, err := r.readCoords(b[4:], byteOrder, mplen, hasZ, hasM, nil)
+	if err != nil {
+		return geo.Sequence{}, 0, err
+	}
+
+	return geo.NewSequence(flat, hasZ, hasM), n + 4, nil
+}
+
+// readCoords reads count points with specified
+// dimensions and appends their coordinates to dst
+func (r *reader) readCoords(
+	b []byte, byteOrder binary.ByteOrder, count int, hasZ, hasM bool, dst []float64,
+) ([]float64, int, error) {
+	size := pointSize(hasZ, hasM)
+	stride := size / 8
+	if dst == nil {
+		dst = make([]float64, 0, capCount(count, b, size)*stride)
+	}
+
+	offset := 0
+	r.enter("point")
+	for idx := 0; idx < count; idx++ {
+		r.at(idx)
+		if len(b)-offset < size {
+			return nil, 0, r.error(b, offset, ErrTruncated)
+		}
+
+		dst = appendCoords(b[offset:], byteOrder, stride, dst)
+		offset += size
+	}
+	r.leave()
+
+	return dst, offset, nil
+}
+
+// appendCoords appends stride coordinate values of point to dst.
+// Length of b must be checked by caller
+func appendCoords(b []byte, byteOrder binary.ByteOrder, stride int, dst []float64) []float64 {
+	for idx := 0; idx < stride; idx++ {
+		dst = append(dst, math.Float64frombits(byteOrder.Uint64(b[idx*8:])))
+	}
+
+	return dst
+}
+
+// readPointMembers reads points of MultiPoint,
+// every one of which is preceded by its own header
+// with dimensions of wkbType, into flat sequence
+func (r *reader) readPointMembers(
+	b []byte, byteOrder binary.ByteOrder, wkbType uint32,
+) (geo.Sequence, int, error) {
+	mplen, err := r.readCount(b, byteOrder, r.addParts)
+	if err != nil {
+		return geo.Sequence{}, 0, err
+	}
+
+	if err = r.addPoints(mplen); err != nil {
+		return geo.Sequence{}, 0, r.error(b, 0, err)
+	}
+
+	parent := header{wkbType: wkbType}
+	hasZ, hasM := parent.HasZ(), parent.HasM()
+	size := pointSize(hasZ, hasM)
+	flat := make([]float64, 0, capCount(mplen, b[4:], headerSize(false)+size)*size/8)
 	var n int
+	var h header
+	var bo binary.ByteOrder
 	offset := 4
+	r.enter("point")
 	for idx := 0; idx < mplen; idx++ {
-		points[idx], n, err = readFunc(b[offset:], byteOrder)
+		r.at(idx)
+		h, bo, n, err = r.readMemberHeader(b[offset:], PointType)
 		if err != nil {
-			return nil, 0, err
+			return geo.Sequence{}, 0, err
+		}
+		if err = checkDims(parent, h); err != nil {
+			return geo.Sequence{}, 0, r.error(b, offset, err)
 		}
-
 		offset += n
+
+		if len(b)-offset < size {
+			return geo.Sequence{}, 0, r.error(b, offset, ErrTruncated)
+		}
+
+		flat = appendCoords(b[offset:], bo, size/8, flat)
+		offset += size
 	}
+	r.leave()
 
-	return geo.NewMultiPoint(points), offset, nil
+	return geo.NewSequence(flat, hasZ, hasM), offset, nil
 }
 
-func readPolygon(b []byte, byteOrder binary.ByteOrder,
-	readFunc readPointFunc) (geo.Polygon, int, error) {
-	if len(b) < 4 {
-		return nil, 0, errors.New("out of range")
+// readPolygon reads rings with dimensions
+// of wkbType into flat polygon
+func (r *reader) readPolygon(
+	b []byte, byteOrder binary.ByteOrder, wkbType uint32,
+) (geo.FlatPolygon, int, error) {
+	plen, err := r.readCount(b, byteOrder, r.addRings)
+	if err != nil {
+		return geo.FlatPolygon{}, 0, err
 	}
 
-	plen := int(byteOrder.Uint32(b))
-	rings := make([]geo.MultiPoint, plen)
-	var err error
-	var n int
+	hasZ, hasM := (wkbType&zFlag) == zFlag, (wkbType&mFlag) == mFlag
+	var flat []float64
+	ends := make([]int, 0, capCount(plen, b[4:], 4))
+	var count, n, total int
 	offset := 4
+	r.enter("ring")
 	for idx := 0; idx < plen; idx++ {
-		rings[idx], n, err = readMultiPoint(b[offset:], byteOrder, readFunc)
+		r.at(idx)
+		count, err = r.readCount(b[offset:], byteOrder, r.addPoints)
 		if err != nil {
-			return nil, 0, err
+			return geo.FlatPolygon{}, 0, err
+		}
+		offset += 4
+
+		if flat == nil {
+			size := pointSize(hasZ, hasM)
+			flat = make([]float64, 0, capCount(count, b[offset:], size)*size/8)
+		}
+
+		flat, n, err = r.readCoords(b[offset:], byteOrder, count, hasZ, hasM, flat)
+		if err != nil {
+			return geo.FlatPolygon{}, 0, err
 		}
 		offset += n
+
+		total += count
+		ends = append(ends, total)
 	}
+	r.leave()
 
-	return geo.NewPolygon(rings), offset, nil
+	return geo.NewFlatPolygon(flat, ends, hasZ, hasM), offset, nil
 }
 
-func readMultiLine(
-	b []byte, byteOrder binary.ByteOrder, readFunc readPointFunc,
+// readMultiLine reads lines of MultiLineString, every one of which
+// is preceded by its own header with dimensions of wkbType
+func (r *reader) readMultiLine(
+	b []byte, byteOrder binary.ByteOrder, wkbType uint32,
 ) (geo.MultiLine, int, error) {
-	if len(b) < 4 {
-		return nil, 0, errors.New("out of range")
+	plen, err := r.readCount(b, byteOrder, r.addParts)
+	if err != nil {
+		return nil, 0, err
 	}
 
-	plen := int(byteOrder.Uint32(b))
-	lines := make([]geo.MultiPoint, plen)
-	var err error
+	parent := header{wkbType: wkbType}
+	lines := make([]geo.MultiPoint, 0, capCount(plen, b[4:], headerSize(false)+4))
+	var line geo.Sequence
 	var n int
 	var h header
 	var bo binary.ByteOrder
 	offset := 4
+	r.enter("line")
 	for idx := 0; idx < plen; idx++ {
-		h, bo, n = readHeader(b[offset:])
-		if h.Type() != LineType {
-			return nil, 0, errors.New("not expected geometry type")
+		r.at(idx)
+		h, bo, n, err = r.readMemberHeader(b[offset:], LineType)
+		if err != nil {
+			return nil, 0, err
+		}
+		if err = checkDims(parent, h); err != nil {
+			return nil, 0, r.error(b, offset, err)
 		}
 		offset += n
-		lines[idx], n, err = readMultiPoint(
-			b[offset:], bo,
-			getReadPointFunc(h.wkbType),
-		)
+		line, n, err = r.readMultiPoint(b[offset:], bo, h.wkbType)
 		if err != nil {
 			return nil, 0, err
 		}
+		lines = append(lines, line)
 		offset += n
 	}
+	r.leave()
 
 	return geo.NewMultiLine(lines), offset, nil
 }
 
-func readMultiPolygon(b []byte, byteOrder binary.ByteOrder,
-	readFunc readPointFunc) (geo.MultiPolygon, int, error) {
-	if len(b) < 4 {
-		return nil, 0, errors.New("out of range")
+// readMultiPolygon reads polygons of geometry of type typ
+// (MultiPolygon, PolyhedralSurface or Tin), every one of which
+// is preceded by its own header with dimensions of wkbType
+func (r *reader) readMultiPolygon(
+	b []byte, byteOrder binary.ByteOrder, typ, wkbType uint32,
+) (geo.MultiPolygon, int, error) {
+	c := containers[typ]
+	mplen, err := r.readCount(b, byteOrder, r.addParts)
+	if err != nil {
+		return nil, 0, err
 	}
 
-	mplen := int(byteOrder.Uint32(b))
-	pols := make([]geo.Polygon, mplen)
-	var err error
+	parent := header{wkbType: wkbType}
+	pols := make([]geo.Polygon, 0, capCount(mplen, b[4:], headerSize(false)+4))
+	var poly geo.FlatPolygon
 	var n int
+	var h header
+	var bo binary.ByteOrder
 	offset := 4
+	r.enter(c.name)
 	for idx := 0; idx < mplen; idx++ {
-		pols[idx], n, err = readPolygon(b[offset:], byteOrder, readFunc)
+		r.at(idx)
+		h, bo, n, err = r.readMemberHeader(b[offset:], c.types[0])
 		if err != nil {
 			return nil, 0, err
 		}
-
+		if err = checkDims(parent, h); err != nil {
+			return nil, 0, r.error(b, offset, err)
+		}
+		offset += n
+		if c.types[0] == TriangleType {
+			poly, n, err = r.readTriangle(b[offset:], bo, h.wkbType)
+		} else {
+			poly, n, err = r.readPolygon(b[offset:], bo, h.wkbType)
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+		pols = append(pols, poly)
 		offset += n
 	}
+	r.leave()
 
 	return geo.NewMultiPolygon(pols), offset, nil
 }
 
-func readCollection(b []byte, byteOrder binary.ByteOrder) ([]Geometry, int, error) {
-	clen := byteOrder.Uint32(b)
+// readTriangle reads polygon and checks that it is valid triangle
+func (r *reader) readTriangle(
+	b []byte, byteOrder binary.ByteOrder, wkbType uint32,
+) (geo.FlatPolygon, int, error) {
+	poly, n, err := r.readPolygon(b, byteOrder, wkbType)
+	if err != nil {
+		return poly, 0, err
+	}
+
+	if err = validateTriangle(poly); err != nil {
+		return poly, 0, r.error(b, 0, err)
+	}
+
+	return poly, n, nil
+}
+
+// readLegacyMultiPolygon reads MultiPolygon in legacy layout,
+// where polygons are not preceded by headers
+func (r *reader) readLegacyMultiPolygon(
+	b []byte, byteOrder binary.ByteOrder, wkbType uint32,
+) (geo.MultiPolygon, int, error) {
+	mplen, err := r.readCount(b, byteOrder, r.addParts)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	pols := make([]geo.Polygon, 0, capCount(mplen, b[4:], 4))
+	var poly geo.FlatPolygon
+	var n int
 	offset := 4
+	r.enter("polygon")
+	for idx := 0; idx < mplen; idx++ {
+		r.at(idx)
+		poly, n, err = r.readPolygon(b[offset:], byteOrder, wkbType)
+		if err != nil {
+			return nil, 0, err
+		}
+		pols = append(pols, poly)
+
+		offset += n
+	}
+	r.leave()
 
-	geoms := make([]Geometry, clen)
+	return geo.NewMultiPolygon(pols), offset, nil
+}
+
+// readGeometry reads body of geometry with specified header
+func (r *reader) readGeometry(
+	h header, b []byte, byteOrder binary.ByteOrder, depth int,
+) (Geometry, int, error) {
 	var n int
 	var err error
-	for idx := 0; idx < len(geoms); idx++ {
-		h1, byteOrder1, offset1 := readHeader(b[offset:])
-		offset += offset1
-		switch h1.Type() {
-		case PointType:
-			point := Point{header: h1}
-			point.point, n, err = getReadPointFunc(h1.wkbType)(b[offset:], byteOrder1)
-			geoms[idx] = &point
-		case LineType:
-			line := LineString{header: h1}
-			line.mp, n, err = readMultiPoint(b[offset:], byteOrder1, getReadPointFunc(h1.wkbType))
-			geoms[idx] = &line
-		case PolygonType:
-			poly := Polygon{header: h1}
-			poly.poly, n, err = readPolygon(b[offset:], byteOrder1, getReadPointFunc(h1.wkbType))
-			geoms[idx] = &poly
-		case MultiPointType:
-			mpoint := MultiPoint{header: h1}
-			mpoint.mp, n, err = readMultiPoint(b[offset:], byteOrder1, getReadPointFunc(h1.wkbType))
-			geoms[idx] = &mpoint
-		case MultiLineType:
-			mline := MultiLineString{header: h1}
-			mline.ml, n, err = readMultiLine(b[offset:], byteOrder1, getReadPointFunc(h1.wkbType))
-			geoms[idx] = &mline
-		case MultiPolygonType:
-			mpoly := MultiPolygon{header: h1}
-			mpoly.mp, n, err = readMultiPolygon(b[offset:], byteOrder1, getReadPointFunc(h1.wkbType))
-			geoms[idx] = &mpoly
-		default:
-			return nil, 0, errors.New("not expected geometry type")
+	switch h.Type() {
+	case PointType:
+		if err = r.addPoints(1); err != nil {
+			return nil, 0, r.error(b, 0, err)
+		}
+
+		point := Point{header: h}
+		point.point, n, err = r.readPoint(b, byteOrder, getReadPointFunc(h.wkbType))
+		return &point, n, err
+	case LineType:
+		line := LineString{header: h}
+		line.mp, n, err = r.readMultiPoint(b, byteOrder, h.wkbType)
+		return &line, n, err
+	case PolygonType:
+		poly := Polygon{header: h}
+		poly.poly, n, err = r.readPolygon(b, byteOrder, h.wkbType)
+		return &poly, n, err
+	case MultiPointType:
+		mpoint := MultiPoint{header: h}
+		if r.opts.LegacyMulti {
+			mpoint.mp, n, err = r.readMultiPoint(b, byteOrder, h.wkbType)
+		} else {
+			mpoint.mp, n, err = r.readPointMembers(b, byteOrder, h.wkbType)
+		}
+		return &mpoint, n, err
+	case MultiLineType:
+		mline := MultiLineString{header: h}
+		mline.ml, n, err = r.readMultiLine(b, byteOrder, h.wkbType)
+		return &mline, n, err
+	case MultiPolygonType:
+		mpoly := MultiPolygon{header: h}
+		if r.opts.LegacyMulti {
+			mpoly.mp, n, err = r.readLegacyMultiPolygon(b, byteOrder, h.wkbType)
+		} else {
+			mpoly.mp, n, err = r.readMultiPolygon(b, byteOrder, MultiPolygonType, h.wkbType)
 		}
+		return &mpoly, n, err
+	case CollectionType:
+		gc := GeometryCollection{header: h}
+		gc.geoms, n, err = r.readCollection(h, b, byteOrder, depth+1)
+		return &gc, n, err
+	case CircularStringType:
+		cs := CircularString{header: h}
+		cs.mp, n, err = r.readMultiPoint(b, byteOrder, h.wkbType)
+		return &cs, n, err
+	case CompoundCurveType:
+		cc := CompoundCurve{header: h}
+		cc.curves, n, err = r.readMembers(h, b, byteOrder, CompoundCurveType, depth)
+		return &cc, n, err
+	case CurvePolygonType:
+		cp := CurvePolygon{header: h}
+		cp.rings, n, err = r.readMembers(h, b, byteOrder, CurvePolygonType, depth)
+		return &cp, n, err
+	case MultiCurveType:
+		mc := MultiCurve{header: h}
+		mc.curves, n, err = r.readMembers(h, b, byteOrder, MultiCurveType, depth)
+		return &mc, n, err
+	case MultiSurfaceType:
+		ms := MultiSurface{header: h}
+		ms.surfaces, n, err = r.readMembers(h, b, byteOrder, MultiSurfaceType, depth)
+		return &ms, n, err
+	case PolyhedralSurfaceType:
+		ps := PolyhedralSurface{header: h}
+		ps.mp, n, err = r.readMultiPolygon(b, byteOrder, PolyhedralSurfaceType, h.wkbType)
+		return &ps, n, err
+	case TinType:
+		tin := Tin{header: h}
+		tin.mp, n, err = r.readMultiPolygon(b, byteOrder, TinType, h.wkbType)
+		return &tin, n, err
+	case TriangleType:
+		tri := Triangle{header: h}
+		tri.poly, n, err = r.readTriangle(b, byteOrder, h.wkbType)
+		return &tri, n, err
+	default:
+		return nil, 0, r.error(b, 0, ErrUnknownType)
+	}
+}
+
+// readCollection reads members of collection,
+// which is located at specified nesting depth
+// (top level collection has depth 1)
+func (r *reader) readCollection(
+	h header, b []byte, byteOrder binary.ByteOrder, depth int,
+) ([]Geometry, int, error) {
+	if depth > r.opts.maxDepth() {
+		return nil, 0, r.error(b, 0, ErrLimitExceeded)
+	}
 
+	return r.readMembers(h, b, byteOrder, CollectionType, depth)
+}
+
+// readMembers reads members of geometry of type typ,
+// every one of which is preceded by its own header
+func (r *reader) readMembers(
+	h header, b []byte, byteOrder binary.ByteOrder, typ uint32, depth int,
+) ([]Geometry, int, error) {
+	c := containers[typ]
+	clen, err := r.readCount(b, byteOrder, r.addParts)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	geoms := make([]Geometry, 0, capCount(clen, b[4:], headerSize(false)+4))
+	var geom Geometry
+	var n int
+	offset := 4
+	r.enter(c.name)
+	for idx := 0; idx < clen; idx++ {
+		r.at(idx)
+		h1, byteOrder1, offset1, err := r.readHeader(b[offset:])
 		if err != nil {
 			return nil, 0, err
 		}
+		if err = c.checkType(h1.Type()); err != nil {
+			return nil, 0, r.error(b, offset, err)
+		}
+		if err = c.checkMember(h, h1); err != nil {
+			return nil, 0, r.error(b, offset, err)
+		}
+		offset += offset1
+		geom, n, err = r.readGeometry(h1, b[offset:], byteOrder1, depth)
+		if err != nil {
+			return nil, 0, err
+		}
+		geoms = append(geoms, geom)
 		offset += n
 	}
+	r.leave()
 
 	return geoms, offset, nil
 }
+
+// isKnownType checks if geometry type is supported by package
+func isKnownType(typ uint32) bool {
+	return typ >= PointType && typ <= MultiSurfaceType ||
+		typ >= PolyhedralSurfaceType && typ <= TriangleType
+}
+
+// unmarshal decodes whole data into geometry.
+// If typ is not zero, geometry must be of this type
+func unmarshal(data []byte, typ uint32, opts DecodeOptions) (Geometry, error) {
+	r := reader{opts: opts, data: data}
+	if opts.MaxInputSize > 0 && len(data) > opts.MaxInputSize {
+		return nil, r.error(data, 0, ErrLimitExceeded)
+	}
+
+	h, byteOrder, offset, err := r.readHeader(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if typ != 0 && h.Type() != typ {
+		return nil, r.error(data, 0, &TypeMismatchError{Expected: typ, Actual: h.Type()})
+	}
+
+	g, n, err := r.readGeometry(h, data[offset:], byteOrder, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if offset+n != len(data) {
+		return nil, r.error(data, offset+n, ErrTrailingBytes)
+	}
+
+	return g, nil
+}
@@ -0,0 +1,234 @@
+package ewkb
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// GeometrySlice presents one-dimensional PostgreSQL array
+// of geometry objects. NULL elements are presented by nil.
+// Can be used for reading geometry[] values
+// both in text and binary array encodings
+// and for writing them in text encoding
+type GeometrySlice []Geometry
+
+// geometryArrayDelim is delimiter of elements of geometry arrays.
+// PostGIS declares it for geometry and geography types instead of comma
+const geometryArrayDelim = ':'
+
+// Scan implements sql.Scanner interface
+func (s *GeometrySlice) Scan(src interface{}) error {
+	var data []byte
+	switch d := src.(type) {
+	case nil:
+		*s = nil
+		return nil
+	case []byte:
+		data = d
+	case string:
+		data = []byte(d)
+	default:
+		return fmt.Errorf("could not scan geometry array: unsupported source type %T", src)
+	}
+
+	var geoms []Geometry
+	var err error
+	if len(data) > 0 && (data[0] == '{' || data[0] == '[') {
+		geoms, err = parseGeometryArray(string(data))
+	} else {
+		geoms, err = readGeometryArray(data)
+	}
+	if err != nil {
+		return fmt.Errorf("could not scan geometry array: %w", err)
+	}
+
+	*s = geoms
+	return nil
+}
+
+// Value implements sql driver.Valuer interface.
+// Elements are written as hex encoded EWKB
+func (s GeometrySlice) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for idx, g := range s {
+		if idx > 0 {
+			sb.WriteByte(geometryArrayDelim)
+		}
+
+		if g == nil {
+			sb.WriteString("NULL")
+			continue
+		}
+
+		data, err := g.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		sb.WriteString(hex.EncodeToString(data))
+	}
+	sb.WriteByte('}')
+
+	return sb.String(), nil
+}
+
+// parseGeometryArray parses text encoding of array,
+// elements of which are hex encoded EWKB
+func parseGeometryArray(s string) ([]Geometry, error) {
+	// Skip optional dimensions decoration, like [1:2]=
+	if s[0] == '[' {
+		idx := strings.IndexByte(s, '=')
+		if idx < 0 {
+			return nil, errors.New("invalid array dimensions")
+		}
+		s = s[idx+1:]
+	}
+
+	if len(s) < 2 || s[0] != '{' || s[len(s)-1] != '}' {
+		return nil, errors.New("array must be enclosed in braces")
+	}
+
+	s = s[1 : len(s)-1]
+	geoms := []Geometry{}
+	if strings.TrimSpace(s) == "" {
+		return geoms, nil
+	}
+
+	for pos := 0; ; pos++ {
+		elem, quoted, n, err := parseArrayElement(s[pos:])
+		if err != nil {
+			return nil, err
+		}
+		pos += n
+
+		var g Geometry
+		if quoted || !strings.EqualFold(elem, "NULL") {
+			data, err := hex.DecodeString(elem)
+			if err != nil {
+				return nil, fmt.Errorf("element %d: %w", len(geoms), err)
+			}
+
+			if g, err = Unmarshal(data, DecodeOptions{}); err != nil {
+				return nil, fmt.Errorf("element %d: %w", len(geoms), err)
+			}
+		}
+		geoms = append(geoms, g)
+
+		if pos >= len(s) {
+			return geoms, nil
+		}
+
+		if s[pos] != geometryArrayDelim && s[pos] != ',' {
+			return nil, fmt.Errorf("unexpected %q in array", s[pos])
+		}
+	}
+}
+
+// parseArrayElement parses element of array in text encoding,
+// which may be quoted, and returns it with count of consumed bytes
+func parseArrayElement(s string) (string, bool, int, error) {
+	pos := 0
+	for pos < len(s) && s[pos] == ' ' {
+		pos++
+	}
+
+	if pos < len(s) && s[pos] == '{' {
+		return "", false, 0, errors.New("multidimensional arrays are not supported")
+	}
+
+	if pos >= len(s) || s[pos] != '"' {
+		start := pos
+		for pos < len(s) && s[pos] != geometryArrayDelim && s[pos] != ',' {
+			pos++
+		}
+
+		return strings.TrimSpace(s[start:pos]), false, pos, nil
+	}
+
+	var sb strings.Builder
+	for pos++; pos < len(s); pos++ {
+		switch s[pos] {
+		case '\\':
+			pos++
+			if pos < len(s) {
+				sb.WriteByte(s[pos])
+			}
+		case '"':
+			pos++
+			for pos < len(s) && s[pos] == ' ' {
+				pos++
+			}
+			return sb.String(), true, pos, nil
+		default:
+			sb.WriteByte(s[pos])
+		}
+	}
+
+	return "", false, 0, errors.New("unterminated quoted array element")
+}
+
+// readGeometryArray reads binary encoding of array,
+// elements of which are EWKB
+func readGeometryArray(data []byte) ([]Geometry, error) {
+	if len(data) < 12 {
+		return nil, ErrTruncated
+	}
+
+	// Header consists of count of dimensions,
+	// flags and OID of element type
+	ndim := int32(binary.BigEndian.Uint32(data))
+	switch {
+	case ndim == 0:
+		return []Geometry{}, nil
+	case ndim != 1:
+		return nil, errors.New("multidimensional arrays are not supported")
+	case len(data) < 20:
+		return nil, ErrTruncated
+	}
+
+	// Dimension consists of length and lower bound
+	count := int32(binary.BigEndian.Uint32(data[12:]))
+	if count < 0 {
+		return nil, ErrInvalidCount
+	}
+
+	offset := 20
+	geoms := make([]Geometry, 0, min(int(count), (len(data)-offset)/4))
+	for idx := 0; idx < int(count); idx++ {
+		if len(data)-offset < 4 {
+			return nil, ErrTruncated
+		}
+
+		size := int32(binary.BigEndian.Uint32(data[offset:]))
+		offset += 4
+		if size < 0 {
+			geoms = append(geoms, nil)
+			continue
+		}
+
+		if int(size) > len(data)-offset {
+			return nil, ErrTruncated
+		}
+
+		g, err := Unmarshal(data[offset:offset+int(size)], DecodeOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", idx, err)
+		}
+		geoms = append(geoms, g)
+		offset += int(size)
+	}
+
+	if offset != len(data) {
+		return nil, ErrTrailingBytes
+	}
+
+	return geoms, nil
+}
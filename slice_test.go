@@ -0,0 +1,132 @@
+package ewkb
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+const (
+	slicePoint = "0101000000000000000000f03f0000000000000040"
+	sliceLine  = "01020000000200000000000000000000000000000000000000000000000000f03f000000000000f03f"
+)
+
+func TestGeometrySlice_Scan(t *testing.T) {
+	binary := "00000001" + "00000001" + "00004008" + "00000003" + "00000001" +
+		"00000015" + slicePoint + "ffffffff" + "00000029" + sliceLine
+	binaryData, _ := hex.DecodeString(binary)
+
+	cases := []struct {
+		name     string
+		src      interface{}
+		expected []string
+	}{
+		{"colon delimited", "{" + slicePoint + ":" + sliceLine + "}", []string{"POINT(1 2)", "LINESTRING(0 0,1 1)"}},
+		{"comma delimited", []byte("{" + slicePoint + "," + sliceLine + "}"), []string{"POINT(1 2)", "LINESTRING(0 0,1 1)"}},
+		{"null element", "{" + slicePoint + ":NULL}", []string{"POINT(1 2)", ""}},
+		{"quoted element", `{"` + slicePoint + `", "` + sliceLine + `"}`, []string{"POINT(1 2)", "LINESTRING(0 0,1 1)"}},
+		{"dimensions", "[0:0]={" + slicePoint + "}", []string{"POINT(1 2)"}},
+		{"empty", "{}", []string{}},
+		{"binary", binaryData, []string{"POINT(1 2)", "", "LINESTRING(0 0,1 1)"}},
+		{"binary empty", []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 64, 8}, []string{}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var s GeometrySlice
+			if err := s.Scan(c.src); err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			if len(s) != len(c.expected) {
+				t.Fatalf("Expected %v, got %v\n", len(c.expected), len(s))
+			}
+
+			for idx, g := range s {
+				var gs string
+				if g != nil {
+					gs = g.String()
+				}
+
+				if gs != c.expected[idx] {
+					t.Errorf("Expected %q, got %q\n", c.expected[idx], gs)
+				}
+			}
+		})
+	}
+}
+
+func TestGeometrySlice_Scan_Null(t *testing.T) {
+	s := GeometrySlice{nil}
+	if err := s.Scan(nil); err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	if s != nil {
+		t.Errorf("Expected %v, got %v\n", nil, s)
+	}
+}
+
+func TestGeometrySlice_Scan_Invalid(t *testing.T) {
+	cases := []interface{}{
+		"{" + slicePoint,
+		"{{" + slicePoint + "}}",
+		`{"` + slicePoint + "}",
+		"{" + slicePoint + ";" + sliceLine + "}",
+		"{zz}",
+		"{0102}",
+		[]byte{0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 64, 8, 0, 0, 0, 1, 0, 0, 0, 1, 0, 0, 0, 10},
+		[]byte{0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 64, 8},
+		12,
+	}
+
+	for _, src := range cases {
+		var s GeometrySlice
+		if err := s.Scan(src); err == nil {
+			t.Errorf("Expected: error, got: no errors (%v)\n", src)
+		}
+	}
+}
+
+func TestGeometrySlice_Value(t *testing.T) {
+	p, err := ParseEWKT("POINT(1 2)")
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	l, err := ParseEWKT("LINESTRING(0 0,1 1)")
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	cases := []struct {
+		name     string
+		slice    GeometrySlice
+		expected interface{}
+	}{
+		{"nil", nil, nil},
+		{"empty", GeometrySlice{}, "{}"},
+		{"elements", GeometrySlice{p, nil, l}, "{" + slicePoint + ":NULL:" + sliceLine + "}"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			v, err := c.slice.Value()
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			if v != c.expected {
+				t.Errorf("Expected %v, got %v\n", c.expected, v)
+			}
+
+			var s GeometrySlice
+			if err = s.Scan(v); err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			if len(s) != len(c.slice) {
+				t.Errorf("Expected %v, got %v\n", len(c.slice), len(s))
+			}
+		})
+	}
+}
@@ -0,0 +1,269 @@
+package ewkb
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+)
+
+// streamChunkSize limits count of bytes, allocated
+// by Decoder for a single read, so that huge counts
+// in malformed data do not cause huge allocations
+const streamChunkSize = 64 * 1024
+
+// Decoder presents decoder of geometry objects
+// from stream of concatenated binary records
+type Decoder struct {
+	r   io.Reader
+	buf []byte
+	// Options are used for decoding of every record.
+	// MaxInputSize limits size of single record
+	Options DecodeOptions
+}
+
+// NewDecoder returns new decoder, reading from r.
+// Decoder reads exactly one record at a time
+// and never reads beyond the end of decoded record
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: r}
+}
+
+// Decode reads next record from stream and decodes it.
+// At the end of stream io.EOF is returned
+func (d *Decoder) Decode() (Geometry, error) {
+	d.buf = d.buf[:0]
+	if err := d.readGeometry(0); err != nil {
+		return nil, err
+	}
+
+	return unmarshal(d.buf, 0, d.Options)
+}
+
+// read reads n bytes from stream, appends them
+// to the record being read and returns them
+func (d *Decoder) read(n int) ([]byte, error) {
+	start := len(d.buf)
+	if d.Options.MaxInputSize > 0 && start+n > d.Options.MaxInputSize {
+		return nil, &DecodeError{Err: ErrLimitExceeded, Offset: start}
+	}
+
+	for n > 0 {
+		chunk := n
+		if chunk > streamChunkSize {
+			chunk = streamChunkSize
+		}
+
+		l := len(d.buf)
+		if cap(d.buf)-l < chunk {
+			buf := make([]byte, l, 2*cap(d.buf)+chunk)
+			copy(buf, d.buf)
+			d.buf = buf
+		}
+		d.buf = d.buf[:l+chunk]
+
+		if _, err := io.ReadFull(d.r, d.buf[l:]); err != nil {
+			if err == io.EOF && l == 0 {
+				return nil, io.EOF
+			}
+
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				return nil, &DecodeError{Err: ErrTruncated, Offset: l}
+			}
+
+			return nil, err
+		}
+
+		n -= chunk
+	}
+
+	return d.buf[start:], nil
+}
+
+// readHeader reads header of geometry and returns
+// its type with dimension flags and byte order
+func (d *Decoder) readHeader() (uint32, binary.ByteOrder, error) {
+	start := len(d.buf)
+	b, err := d.read(headerSize(false))
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if b[0] != XDR && b[0] != NDR {
+		return 0, nil, &DecodeError{Err: ErrInvalidByteOrder, Offset: start}
+	}
+
+	byteOrder := getBinaryByteOrder(b[0])
+	wkbType := normalizeType(byteOrder.Uint32(b[1:]))
+	if !isKnownType(wkbType & uint32(math.MaxUint16)) {
+		return 0, nil, &DecodeError{Err: ErrUnknownType, Offset: start}
+	}
+
+	if (wkbType & sridFlag) == sridFlag {
+		if _, err = d.read(4); err != nil {
+			return 0, nil, err
+		}
+	}
+
+	return wkbType, byteOrder, nil
+}
+
+// readCount reads count of elements
+func (d *Decoder) readCount(byteOrder binary.ByteOrder) (int, error) {
+	b, err := d.read(4)
+	if err != nil {
+		return 0, err
+	}
+
+	count := byteOrder.Uint32(b)
+	if uint64(count) > math.MaxInt32 {
+		return 0, &DecodeError{Err: ErrInvalidCount, Offset: len(d.buf) - 4}
+	}
+
+	return int(count), nil
+}
+
+// readPoints reads count of points and points themselves
+func (d *Decoder) readPoints(byteOrder binary.ByteOrder, size int) error {
+	count, err := d.readCount(byteOrder)
+	if err != nil {
+		return err
+	}
+
+	if uint64(count)*uint64(size) > math.MaxInt32 {
+		return &DecodeError{Err: ErrInvalidCount, Offset: len(d.buf) - 4}
+	}
+
+	_, err = d.read(count * size)
+	return err
+}
+
+func (d *Decoder) readPolygon(byteOrder binary.ByteOrder, size int) error {
+	count, err := d.readCount(byteOrder)
+	if err != nil {
+		return err
+	}
+
+	for idx := 0; idx < count; idx++ {
+		if err = d.readPoints(byteOrder, size); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readMembers reads members of geometry of type typ,
+// every one of which is preceded by its own header
+func (d *Decoder) readMembers(byteOrder binary.ByteOrder, typ uint32, depth int) error {
+	count, err := d.readCount(byteOrder)
+	if err != nil {
+		return err
+	}
+
+	c := containers[typ]
+	for idx := 0; idx < count; idx++ {
+		start := len(d.buf)
+		wkbType, bo, err := d.readHeader()
+		if err != nil {
+			return err
+		}
+
+		if err = c.checkType(wkbType & uint32(math.MaxUint16)); err != nil {
+			return &DecodeError{Err: err, Offset: start}
+		}
+
+		if err = d.readBody(wkbType, bo, depth); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readGeometry reads whole geometry, located
+// at specified nesting depth of collections
+func (d *Decoder) readGeometry(depth int) error {
+	wkbType, byteOrder, err := d.readHeader()
+	if err != nil {
+		return err
+	}
+
+	return d.readBody(wkbType, byteOrder, depth)
+}
+
+// readBody reads body of geometry with specified type
+func (d *Decoder) readBody(wkbType uint32, byteOrder binary.ByteOrder, depth int) error {
+	size := pointSize((wkbType&zFlag) == zFlag, (wkbType&mFlag) == mFlag)
+	switch wkbType & uint32(math.MaxUint16) {
+	case PointType:
+		_, err := d.read(size)
+		return err
+	case LineType:
+		return d.readPoints(byteOrder, size)
+	case PolygonType, TriangleType:
+		return d.readPolygon(byteOrder, size)
+	case MultiPointType:
+		if d.Options.LegacyMulti {
+			return d.readPoints(byteOrder, size)
+		}
+		return d.readMembers(byteOrder, MultiPointType, depth)
+	case MultiLineType:
+		return d.readMembers(byteOrder, MultiLineType, depth)
+	case MultiPolygonType:
+		if !d.Options.LegacyMulti {
+			return d.readMembers(byteOrder, MultiPolygonType, depth)
+		}
+
+		count, err := d.readCount(byteOrder)
+		if err != nil {
+			return err
+		}
+
+		for idx := 0; idx < count; idx++ {
+			if err = d.readPolygon(byteOrder, size); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case CollectionType:
+		if depth+1 > d.Options.maxDepth() {
+			return &DecodeError{Err: ErrLimitExceeded, Offset: len(d.buf)}
+		}
+
+		return d.readMembers(byteOrder, CollectionType, depth+1)
+	case CircularStringType:
+		return d.readPoints(byteOrder, size)
+	case CompoundCurveType, CurvePolygonType, MultiCurveType, MultiSurfaceType,
+		PolyhedralSurfaceType, TinType:
+		return d.readMembers(byteOrder, wkbType&uint32(math.MaxUint16), depth)
+	default:
+		return &DecodeError{Err: ErrUnknownType, Offset: len(d.buf)}
+	}
+}
+
+// Encoder presents encoder of geometry objects
+// into stream of concatenated binary records
+type Encoder struct {
+	w   io.Writer
+	buf []byte
+	// Options are used for encoding of every record
+	Options EncodeOptions
+}
+
+// NewEncoder returns new encoder, writing to w
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// Encode writes binary representation of geometry to stream
+func (e *Encoder) Encode(g Geometry) error {
+	var err error
+	e.buf, err = AppendBinary(e.buf[:0], g, e.Options)
+	if err != nil {
+		return err
+	}
+
+	_, err = e.w.Write(e.buf)
+	return err
+}
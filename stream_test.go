@@ -0,0 +1,113 @@
+package ewkb
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestDecoder_Decode(t *testing.T) {
+	geoms := []string{
+		"SRID=4326;POINT(1 2)",
+		"LINESTRING(1 2 3,4 5 6)",
+		"POLYGON((0 0,1 1,1 0,0 0))",
+		"MULTIPOINT(1 2,3 4)",
+		"MULTILINESTRING((1 2,3 4),(5 6,7 8))",
+		"MULTIPOLYGON(((0 0,1 1,1 0,0 0)),((0 0,1 1,1 0,0 0)))",
+		"GEOMETRYCOLLECTION(POINT(1 2),GEOMETRYCOLLECTION(LINESTRING(1 2,3 4)))",
+	}
+
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+	for _, s := range geoms {
+		g, err := ParseEWKT(s)
+		if err != nil {
+			t.Fatalf("Expected: no errors, got error: %v\n", err)
+		}
+
+		if err = enc.Encode(g); err != nil {
+			t.Fatalf("Expected: no errors, got error: %v\n", err)
+		}
+	}
+	buf.WriteString("tail")
+
+	dec := NewDecoder(&buf)
+	for _, s := range geoms {
+		g, err := dec.Decode()
+		if err != nil {
+			t.Fatalf("Expected: no errors, got error: %v\n", err)
+		}
+
+		if g.String() != s {
+			t.Errorf("Expected %q, got %q\n", s, g.String())
+		}
+	}
+
+	if rest := buf.String(); rest != "tail" {
+		t.Errorf("Expected %q, got %q\n", "tail", rest)
+	}
+}
+
+func TestDecoder_Decode_EOF(t *testing.T) {
+	p, err := ParseEWKT("POINT(1 2)")
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	data, err := p.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	dec := NewDecoder(bytes.NewReader(data))
+	if _, err = dec.Decode(); err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	if _, err = dec.Decode(); err != io.EOF {
+		t.Errorf("Expected %v, got %v\n", io.EOF, err)
+	}
+
+	dec = NewDecoder(bytes.NewReader(data[:len(data)-1]))
+	if _, err = dec.Decode(); !errors.Is(err, ErrTruncated) {
+		t.Errorf("Expected %v, got %v\n", ErrTruncated, err)
+	}
+}
+
+func TestDecoder_Decode_Malformed(t *testing.T) {
+	cases := []struct {
+		name     string
+		data     []byte
+		opts     DecodeOptions
+		expected error
+	}{
+		{"invalid byte order", []byte{2, 1, 0, 0, 0}, DecodeOptions{}, ErrInvalidByteOrder},
+		{"unknown type", []byte{1, 99, 0, 0, 0}, DecodeOptions{}, ErrUnknownType},
+		{"huge line", []byte{1, 2, 0, 0, 0, 255, 255, 255, 255}, DecodeOptions{}, ErrInvalidCount},
+		{"truncated line", []byte{1, 2, 0, 0, 0, 0, 0, 1, 0, 0}, DecodeOptions{}, ErrTruncated},
+		{
+			"member type mismatch",
+			[]byte{1, 4, 0, 0, 0, 1, 0, 0, 0, 1, 2, 0, 0, 0},
+			DecodeOptions{},
+			ErrTypeMismatch,
+		},
+		{
+			"input size",
+			[]byte{1, 2, 0, 0, 0, 2, 0, 0, 0},
+			DecodeOptions{MaxInputSize: 20},
+			ErrLimitExceeded,
+		},
+		{"depth", nestedCollectionData, DecodeOptions{MaxDepth: 1}, ErrLimitExceeded},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			dec := NewDecoder(bytes.NewReader(c.data))
+			dec.Options = c.opts
+			if _, err := dec.Decode(); !errors.Is(err, c.expected) {
+				t.Errorf("Expected %v, got %v\n", c.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package ewkb
+
+import (
+	"database/sql/driver"
+
+	"github.com/kcasctiv/go-ewkb/geo"
+)
+
+// Tin presents Tin geometry object,
+// which is surface, consisting of triangles
+type Tin struct {
+	header
+	mp geo.MultiPolygon
+}
+
+// NewTin returns new Tin,
+// created from geometry base and coords data
+func NewTin(b Base, mp geo.MultiPolygon) Tin {
+	return Tin{
+		header: header{
+			byteOrder: b.ByteOrder(),
+			wkbType: getFlags(
+				b.HasZ(),
+				b.HasM(),
+				b.HasSRID(),
+			) | TinType,
+			srid: b.SRID(),
+		},
+		mp: mp,
+	}
+}
+
+// NewEmptyTin returns new empty Tin,
+// created from geometry base
+func NewEmptyTin(b Base) Tin {
+	return NewTin(b, geo.NewMultiPolygon(nil))
+}
+
+// Triangle returns triangle with specified index
+func (t *Tin) Triangle(idx int) geo.Polygon { return t.mp.Polygon(idx) }
+
+// Len returns count of triangles
+func (t *Tin) Len() int { return t.mp.Len() }
+
+// IsEmpty checks if Tin has no triangles or all its triangles are empty
+func (t *Tin) IsEmpty() bool {
+	for idx := 0; idx < t.Len(); idx++ {
+		if !isEmptyPolygon(t.Triangle(idx)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Validate checks that dimensions of all points
+// match dimensions of TIN, and its triangles are valid
+func (t *Tin) Validate() error { return validateGeometry(t) }
+
+// String returns WKT/EWKT geometry representation
+func (t *Tin) String() string { return sridPrefix(t) + t.wkt() }
+
+// wkt returns WKT geometry representation
+func (t *Tin) wkt() string {
+	s := "TIN" + wktDims(t)
+
+	if t.Len() == 0 {
+		s += " EMPTY"
+		return s
+	}
+
+	s += "("
+	if t.Len() > 0 {
+		for idx := 0; idx < t.Len(); idx++ {
+			s += printPolygon(t.Triangle(idx), t.HasZ(), t.HasM()) + ","
+		}
+
+		s = s[:len(s)-1]
+	}
+
+	return s + ")"
+}
+
+// Scan implements sql.Scanner interface
+func (t *Tin) Scan(src interface{}) error {
+	return scanGeometry(src, t)
+}
+
+// Value implements sql driver.Valuer interface
+func (t *Tin) Value() (driver.Value, error) {
+	return geometryValue(t, DefaultValueFormat)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler interface
+func (t *Tin) UnmarshalBinary(data []byte) error {
+	g, err := unmarshal(data, TinType, DecodeOptions{})
+	if err != nil {
+		return err
+	}
+
+	*t = *g.(*Tin)
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler interface
+func (t *Tin) MarshalBinary() ([]byte, error) {
+	return t.appendBinary(nil, EncodeOptions{})
+}
+
+// AppendBinary appends binary representation of geometry to dst
+// and returns extended slice. Implements encoding.BinaryAppender interface
+func (t *Tin) AppendBinary(dst []byte) ([]byte, error) {
+	return t.appendBinary(dst, EncodeOptions{})
+}
+
+// EncodedSize returns size of binary representation of geometry
+func (t *Tin) EncodedSize() int {
+	return t.encodedSize(EncodeOptions{})
+}
+
+func (t *Tin) encodedSize(opts EncodeOptions) int {
+	return headerSize(t.HasSRID() && !opts.ISO) + multiPolygonSize(t.mp, t.HasZ(), t.HasM())
+}
+
+func (t *Tin) appendBinary(dst []byte, opts EncodeOptions) ([]byte, error) {
+	for idx := 0; idx < t.Len(); idx++ {
+		if err := validateTriangle(t.Triangle(idx)); err != nil {
+			return dst, err
+		}
+	}
+
+	hasSRID := t.HasSRID() && !opts.ISO
+	dst, b := grow(dst, t.encodedSize(opts))
+
+	byteOrder := getBinaryByteOrder(t.ByteOrder())
+	offset := writeHeader(t, t.Type(), byteOrder, hasSRID, opts, b)
+	writeMultiPolygon(t, t.mp, TriangleType, byteOrder, opts, b[offset:])
+
+	return dst, nil
+}
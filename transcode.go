@@ -0,0 +1,200 @@
+package ewkb
+
+// WithSRID returns copy of geometry with specified SRID,
+// as PostGIS ST_SetSRID does. Zero SRID removes SRID from geometry.
+// Members of collections, which have SRID, get the new SRID too,
+// so they stay consistent with collection. Coordinates are shared
+// with source geometry
+func WithSRID(g Geometry, srid int32) Geometry {
+	set := func(h *header) {
+		h.srid = srid
+		h.wkbType &^= sridFlag
+		if srid != 0 {
+			h.wkbType |= sridFlag
+		}
+	}
+
+	return withHeader(g, set, func(h *header) {
+		if h.HasSRID() {
+			set(h)
+		}
+	})
+}
+
+// WithByteOrder returns copy of geometry with specified byte order
+// (XDR or NDR). Members of collections get the new byte order too.
+// Coordinates are shared with source geometry
+func WithByteOrder(g Geometry, byteOrder byte) (Geometry, error) {
+	if byteOrder != XDR && byteOrder != NDR {
+		return nil, ErrInvalidByteOrder
+	}
+
+	set := func(h *header) { h.byteOrder = byteOrder }
+	return withHeader(g, set, set), nil
+}
+
+// withHeader returns shallow copy of geometry, header of which
+// is modified by f, and headers of members are modified by member
+func withHeader(geom Geometry, f, member func(*header)) Geometry {
+	switch g := geom.(type) {
+	case *Point:
+		c := *g
+		f(&c.header)
+		return &c
+	case *LineString:
+		c := *g
+		f(&c.header)
+		return &c
+	case *Polygon:
+		c := *g
+		f(&c.header)
+		return &c
+	case *MultiPoint:
+		c := *g
+		f(&c.header)
+		return &c
+	case *MultiLineString:
+		c := *g
+		f(&c.header)
+		return &c
+	case *MultiPolygon:
+		c := *g
+		f(&c.header)
+		return &c
+	case *GeometryCollection:
+		c := *g
+		f(&c.header)
+		c.geoms = withMembersHeader(g.geoms, member)
+		return &c
+	case *CircularString:
+		c := *g
+		f(&c.header)
+		return &c
+	case *CompoundCurve:
+		c := *g
+		f(&c.header)
+		c.curves = withMembersHeader(g.curves, member)
+		return &c
+	case *CurvePolygon:
+		c := *g
+		f(&c.header)
+		c.rings = withMembersHeader(g.rings, member)
+		return &c
+	case *MultiCurve:
+		c := *g
+		f(&c.header)
+		c.curves = withMembersHeader(g.curves, member)
+		return &c
+	case *MultiSurface:
+		c := *g
+		f(&c.header)
+		c.surfaces = withMembersHeader(g.surfaces, member)
+		return &c
+	case *PolyhedralSurface:
+		c := *g
+		f(&c.header)
+		return &c
+	case *Tin:
+		c := *g
+		f(&c.header)
+		return &c
+	case *Triangle:
+		c := *g
+		f(&c.header)
+		return &c
+	default:
+		return geom
+	}
+}
+
+func withMembersHeader(geoms []Geometry, f func(*header)) []Geometry {
+	if geoms == nil {
+		return nil
+	}
+
+	members := make([]Geometry, len(geoms))
+	for idx, geom := range geoms {
+		members[idx] = withHeader(geom, f, f)
+	}
+
+	return members
+}
+
+// Transcode returns copy of EWKB data, in which geometry
+// and all its members are encoded with specified byte order
+// (XDR or NDR). Data is validated, as by NewView,
+// and transcoded without decoding of geometry objects.
+// Legacy layout of multi geometry objects is not supported
+func Transcode(data []byte, byteOrder byte) ([]byte, error) {
+	if byteOrder != XDR && byteOrder != NDR {
+		return nil, ErrInvalidByteOrder
+	}
+
+	if _, err := NewView(data, DecodeOptions{}); err != nil {
+		return nil, err
+	}
+
+	dst := make([]byte, len(data))
+	copy(dst, data)
+	transcodeGeometry(dst, byteOrder)
+
+	return dst, nil
+}
+
+// transcodeGeometry changes byte order of valid geometry
+// at the beginning of b in place and returns its size
+func transcodeGeometry(b []byte, byteOrder byte) int {
+	h, order, offset, _ := readHeader(b)
+	swap := b[0] != byteOrder
+	if swap {
+		b[0] = byteOrder
+		swapBytes(b[1:5])
+		if h.HasSRID() {
+			swapBytes(b[5:9])
+		}
+	}
+
+	count := func() int {
+		n := int(order.Uint32(b[offset:]))
+		if swap {
+			swapBytes(b[offset : offset+4])
+		}
+		offset += 4
+		return n
+	}
+
+	coords := func(n int) {
+		if swap {
+			for end := offset + n*pointSize(h.HasZ(), h.HasM()); offset < end; offset += 8 {
+				swapBytes(b[offset : offset+8])
+			}
+			return
+		}
+
+		offset += n * pointSize(h.HasZ(), h.HasM())
+	}
+
+	switch h.Type() {
+	case PointType:
+		coords(1)
+	case LineType, CircularStringType:
+		coords(count())
+	case PolygonType, TriangleType:
+		for rings := count(); rings > 0; rings-- {
+			coords(count())
+		}
+	default:
+		for members := count(); members > 0; members-- {
+			offset += transcodeGeometry(b[offset:], byteOrder)
+		}
+	}
+
+	return offset
+}
+
+// swapBytes reverses order of bytes of b
+func swapBytes(b []byte) {
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+}
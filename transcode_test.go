@@ -0,0 +1,183 @@
+package ewkb
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+var transcodeCases = []string{
+	"SRID=4326;POINT(1 2)",
+	"POINT(1 2 3 4)",
+	"LINESTRING(0 0,1 1)",
+	"CIRCULARSTRING(0 0,1 1,2 0)",
+	"POLYGON((0 0,0 1,1 1,0 0),(0 0,0 1,1 1,0 0))",
+	"TRIANGLE((0 0,0 1,1 1,0 0))",
+	"MULTIPOINTM(1 2 3,EMPTY)",
+	"MULTILINESTRING((0 0,1 1),EMPTY)",
+	"MULTIPOLYGON(((0 0,0 1,1 1,0 0)))",
+	"SRID=3857;GEOMETRYCOLLECTION(POINT(1 2),GEOMETRYCOLLECTION(LINESTRING(0 0,1 1)))",
+	"COMPOUNDCURVE(CIRCULARSTRING(0 0,1 1,2 0),(2 0,3 0))",
+	"CURVEPOLYGON(CIRCULARSTRING(0 0,1 1,2 0,1 -1,0 0))",
+	"MULTICURVE((0 0,1 1))",
+	"MULTISURFACE(((0 0,0 1,1 1,0 0)))",
+	"POLYHEDRALSURFACE(((0 0,0 1,1 1,0 0)))",
+	"TIN(((0 0,0 1,1 1,0 0)))",
+}
+
+func TestWithByteOrder(t *testing.T) {
+	for _, c := range transcodeCases {
+		t.Run(c, func(t *testing.T) {
+			g, err := ParseEWKT(c)
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			ndr, err := g.MarshalBinary()
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			xg, err := WithByteOrder(g, XDR)
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			if xg.ByteOrder() != XDR || g.ByteOrder() != NDR {
+				t.Errorf("Expected %v and %v, got %v and %v\n", XDR, NDR, xg.ByteOrder(), g.ByteOrder())
+			}
+
+			xdr, err := xg.MarshalBinary()
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			transcoded, err := Transcode(ndr, XDR)
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			if !bytes.Equal(transcoded, xdr) {
+				t.Errorf("Expected %x, got %x\n", xdr, transcoded)
+			}
+
+			if transcoded, err = Transcode(xdr, NDR); err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			if !bytes.Equal(transcoded, ndr) {
+				t.Errorf("Expected %x, got %x\n", ndr, transcoded)
+			}
+
+			if s := xg.String(); s != c {
+				t.Errorf("Expected %q, got %q\n", c, s)
+			}
+		})
+	}
+}
+
+func TestWithByteOrder_Invalid(t *testing.T) {
+	g, err := ParseEWKT("POINT(1 2)")
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	if _, err = WithByteOrder(g, 2); !errors.Is(err, ErrInvalidByteOrder) {
+		t.Errorf("Expected %v, got %v\n", ErrInvalidByteOrder, err)
+	}
+}
+
+func TestWithSRID(t *testing.T) {
+	cases := []struct {
+		wkt  string
+		srid int32
+		exp  string
+	}{
+		{"POINT(1 2)", 4326, "SRID=4326;POINT(1 2)"},
+		{"SRID=4326;POINT(1 2)", 3857, "SRID=3857;POINT(1 2)"},
+		{"SRID=4326;POINT(1 2)", 0, "POINT(1 2)"},
+		{"SRID=4326;TIN(((0 0,0 1,1 1,0 0)))", 3857, "SRID=3857;TIN(((0 0,0 1,1 1,0 0)))"},
+		{
+			"SRID=4326;GEOMETRYCOLLECTION(POINT(1 2),MULTICURVE((0 0,1 1)))", 3857,
+			"SRID=3857;GEOMETRYCOLLECTION(POINT(1 2),MULTICURVE((0 0,1 1)))",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.exp, func(t *testing.T) {
+			g, err := ParseEWKT(c.wkt)
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			sg := WithSRID(g, c.srid)
+			if s := sg.String(); s != c.exp {
+				t.Errorf("Expected %q, got %q\n", c.exp, s)
+			}
+
+			if s := g.String(); s != c.wkt {
+				t.Errorf("Source geometry changed: expected %q, got %q\n", c.wkt, s)
+			}
+
+			if _, err = sg.MarshalBinary(); err != nil {
+				t.Errorf("Expected: no errors, got error: %v\n", err)
+			}
+		})
+	}
+}
+
+func TestWithSRID_Members(t *testing.T) {
+	g, err := ParseEWKT("SRID=4326;POINT(1 2)")
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	l, err := ParseEWKT("LINESTRING(0 0,1 1)")
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	c := NewGeometryCollection(g, []Geometry{g, l})
+	sc := WithSRID(&c, 3857).(*GeometryCollection)
+	if p := sc.Geometry(0); p.SRID() != 3857 {
+		t.Errorf("Expected %v, got %v\n", 3857, p.SRID())
+	}
+
+	if m := sc.Geometry(1); m.HasSRID() {
+		t.Errorf("Expected %v, got %v\n", false, m.HasSRID())
+	}
+
+	if err = sc.Validate(); err != nil {
+		t.Errorf("Expected: no errors, got error: %v\n", err)
+	}
+}
+
+func TestTranscode_MixedMembers(t *testing.T) {
+	// NDR collection with XDR point
+	data, _ := hex.DecodeString("0107000000010000000000000001" + "3ff00000000000004000000000000000")
+	expected := "0107000000010000000101000000000000000000f03f0000000000000040"
+	res, err := Transcode(data, NDR)
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	if hex.EncodeToString(res) != expected {
+		t.Errorf("Expected %v, got %x\n", expected, res)
+	}
+
+	if data[9] != XDR {
+		t.Errorf("Source data changed: %x\n", data)
+	}
+}
+
+func TestTranscode_Invalid(t *testing.T) {
+	if _, err := Transcode([]byte{1, 1, 0, 0, 0, 0}, XDR); !errors.Is(err, ErrTruncated) {
+		t.Errorf("Expected %v, got %v\n", ErrTruncated, err)
+	}
+
+	data, _ := hex.DecodeString("0101000000000000000000f03f0000000000000040")
+	if _, err := Transcode(data, 7); !errors.Is(err, ErrInvalidByteOrder) {
+		t.Errorf("Expected %v, got %v\n", ErrInvalidByteOrder, err)
+	}
+}
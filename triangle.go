@@ -0,0 +1,145 @@
+package ewkb
+
+import (
+	"database/sql/driver"
+
+	"github.com/kcasctiv/go-ewkb/geo"
+)
+
+// Triangle presents Triangle geometry object,
+// which is polygon with single ring of four closed points
+type Triangle struct {
+	header
+	poly geo.Polygon
+}
+
+// NewTriangle returns new Triangle,
+// created from geometry base and coords data
+func NewTriangle(b Base, poly geo.Polygon) Triangle {
+	return Triangle{
+		header: header{
+			byteOrder: b.ByteOrder(),
+			wkbType: getFlags(
+				b.HasZ(),
+				b.HasM(),
+				b.HasSRID(),
+			) | TriangleType,
+			srid: b.SRID(),
+		},
+		poly: poly,
+	}
+}
+
+// NewEmptyTriangle returns new empty Triangle,
+// created from geometry base
+func NewEmptyTriangle(b Base) Triangle {
+	return NewTriangle(b, geo.NewPolygon(nil))
+}
+
+// Ring returns ring with specified index
+func (t *Triangle) Ring(idx int) geo.MultiPoint { return t.poly.Ring(idx) }
+
+// Len returns count of rings
+func (t *Triangle) Len() int { return t.poly.Len() }
+
+// IsEmpty checks if Triangle has no rings or its ring has no points
+func (t *Triangle) IsEmpty() bool { return isEmptyPolygon(t) }
+
+// Validate checks that dimensions of all points
+// match dimensions of triangle, and it has four closed points
+func (t *Triangle) Validate() error { return validateGeometry(t) }
+
+// String returns WKT/EWKT geometry representation
+func (t *Triangle) String() string { return sridPrefix(t) + t.wkt() }
+
+// wkt returns WKT geometry representation
+func (t *Triangle) wkt() string {
+	s := "TRIANGLE" + wktDims(t)
+
+	if t.Len() == 0 {
+		return s + " EMPTY"
+	}
+
+	return s + printPolygon(t, t.HasZ(), t.HasM())
+}
+
+// Scan implements sql.Scanner interface
+func (t *Triangle) Scan(src interface{}) error {
+	return scanGeometry(src, t)
+}
+
+// Value implements sql driver.Valuer interface
+func (t *Triangle) Value() (driver.Value, error) {
+	return geometryValue(t, DefaultValueFormat)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler interface
+func (t *Triangle) UnmarshalBinary(data []byte) error {
+	g, err := unmarshal(data, TriangleType, DecodeOptions{})
+	if err != nil {
+		return err
+	}
+
+	*t = *g.(*Triangle)
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler interface
+func (t *Triangle) MarshalBinary() ([]byte, error) {
+	return t.appendBinary(nil, EncodeOptions{})
+}
+
+// AppendBinary appends binary representation of geometry to dst
+// and returns extended slice. Implements encoding.BinaryAppender interface
+func (t *Triangle) AppendBinary(dst []byte) ([]byte, error) {
+	return t.appendBinary(dst, EncodeOptions{})
+}
+
+// EncodedSize returns size of binary representation of geometry
+func (t *Triangle) EncodedSize() int {
+	return t.encodedSize(EncodeOptions{})
+}
+
+func (t *Triangle) encodedSize(opts EncodeOptions) int {
+	return headerSize(t.HasSRID() && !opts.ISO) + polygonSize(t, t.HasZ(), t.HasM())
+}
+
+func (t *Triangle) appendBinary(dst []byte, opts EncodeOptions) ([]byte, error) {
+	if err := validateTriangle(t); err != nil {
+		return dst, err
+	}
+
+	hasSRID := t.HasSRID() && !opts.ISO
+	dst, b := grow(dst, t.encodedSize(opts))
+
+	byteOrder := getBinaryByteOrder(t.ByteOrder())
+	offset := writeHeader(t, t.Type(), byteOrder, hasSRID, opts, b)
+	writePolygon(t, byteOrder, t.HasZ(), t.HasM(), b[offset:])
+
+	return dst, nil
+}
+
+// validateTriangle checks that triangle is empty
+// or has single ring of four closed points
+func validateTriangle(p geo.Polygon) error {
+	if p.Len() == 0 {
+		return nil
+	}
+
+	if p.Len() != 1 {
+		return ErrInvalidTriangle
+	}
+
+	ring := p.Ring(0)
+	if ring.Len() != 4 {
+		return ErrInvalidTriangle
+	}
+
+	first, last := ring.Point(0), ring.Point(3)
+	if first.X() != last.X() || first.Y() != last.Y() ||
+		first.Z() != last.Z() || first.M() != last.M() {
+		return ErrInvalidTriangle
+	}
+
+	return nil
+}
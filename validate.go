@@ -0,0 +1,226 @@
+package ewkb
+
+import (
+	"strconv"
+
+	"github.com/kcasctiv/go-ewkb/geo"
+)
+
+// validateGeometry checks that dimensions of all points
+// and members of geometry match dimensions of its base.
+// Triangles are checked to be valid too
+func validateGeometry(g Geometry) error {
+	return validate(g, false)
+}
+
+// validateMembers checks that all members of geometry,
+// including nested ones, have dimensions of geometry and
+// either have no SRID or have SRID of geometry.
+// Members are encoded without SRID, and members of other
+// dimensions are rejected by decoders, so such geometry is not encoded.
+// Triangles of members are checked to be valid, as they are
+// checked by encoding of standalone Triangle and Tin
+func validateMembers(g Geometry) error {
+	return validate(g, true)
+}
+
+// validate checks geometry. If membersOnly is set,
+// only headers of members and triangles are checked
+func validate(geom Geometry, membersOnly bool) error {
+	switch g := geom.(type) {
+	case *GeometryCollection:
+		return validateMemberList(g, g.geoms, CollectionType, membersOnly)
+	case *CompoundCurve:
+		return validateMemberList(g, g.curves, CompoundCurveType, membersOnly)
+	case *CurvePolygon:
+		return validateMemberList(g, g.rings, CurvePolygonType, membersOnly)
+	case *MultiCurve:
+		return validateMemberList(g, g.curves, MultiCurveType, membersOnly)
+	case *MultiSurface:
+		return validateMemberList(g, g.surfaces, MultiSurfaceType, membersOnly)
+	case *Point, *LineString, *CircularString, *MultiPoint, *Polygon, *Triangle,
+		*MultiLineString, *MultiPolygon, *PolyhedralSurface, *Tin:
+	default:
+		return &ValidationError{Err: unsupportedType(geom)}
+	}
+
+	if membersOnly {
+		return validateTriangles(geom)
+	}
+
+	layout := geo.NewLayout(geom.HasZ(), geom.HasM())
+	switch g := geom.(type) {
+	case *Point:
+		return validateLayout(g.point, layout)
+	case *LineString:
+		return validateMultiPoint(g.mp, layout)
+	case *CircularString:
+		return validateMultiPoint(g.mp, layout)
+	case *MultiPoint:
+		return validateMultiPoint(g.mp, layout)
+	case *Polygon:
+		return validatePolygon(g.poly, layout)
+	case *Triangle:
+		return validateTrianglePoints(g.poly, layout)
+	case *MultiLineString:
+		return validateMultiLine(g.ml, layout)
+	case *MultiPolygon:
+		return validateMultiPolygon(g.mp, layout, MultiPolygonType)
+	case *PolyhedralSurface:
+		return validateMultiPolygon(g.mp, layout, PolyhedralSurfaceType)
+	case *Tin:
+		return validateMultiPolygon(g.mp, layout, TinType)
+	}
+
+	return nil
+}
+
+// nested returns ValidationError of nested element
+// with specified name and index
+func nested(err error, name string, idx int) error {
+	verr, ok := err.(*ValidationError)
+	if !ok {
+		verr = &ValidationError{Err: err}
+	}
+
+	elem := name + " " + strconv.Itoa(idx)
+	if verr.Path != "" {
+		elem += ", " + verr.Path
+	}
+	verr.Path = elem
+
+	return verr
+}
+
+// layoutHolder is implemented by points
+// and sequences of points of single layout
+type layoutHolder interface {
+	Layout() geo.Layout
+}
+
+// validateLayout checks that point or sequence has specified layout
+func validateLayout(p layoutHolder, layout geo.Layout) error {
+	if actual := p.Layout(); actual != layout {
+		return &ValidationError{Err: &DimensionMismatchError{Expected: layout, Actual: actual}}
+	}
+
+	return nil
+}
+
+func validateMultiPoint(mp geo.MultiPoint, layout geo.Layout) error {
+	// Sequences store points of single layout,
+	// so it is enough to check it once
+	if seq, ok := mp.(layoutHolder); ok {
+		return validateLayout(seq, layout)
+	}
+
+	for idx := 0; idx < mp.Len(); idx++ {
+		if err := validateLayout(mp.Point(idx), layout); err != nil {
+			return nested(err, "point", idx)
+		}
+	}
+
+	return nil
+}
+
+func validatePolygon(p geo.Polygon, layout geo.Layout) error {
+	for idx := 0; idx < p.Len(); idx++ {
+		if err := validateMultiPoint(p.Ring(idx), layout); err != nil {
+			return nested(err, "ring", idx)
+		}
+	}
+
+	return nil
+}
+
+func validateTrianglePoints(p geo.Polygon, layout geo.Layout) error {
+	if err := validatePolygon(p, layout); err != nil {
+		return err
+	}
+
+	if err := validateTriangle(p); err != nil {
+		return &ValidationError{Err: err}
+	}
+
+	return nil
+}
+
+// validateTriangles checks that triangles
+// of Triangle and Tin are valid
+func validateTriangles(geom Geometry) error {
+	switch g := geom.(type) {
+	case *Triangle:
+		if err := validateTriangle(g.poly); err != nil {
+			return &ValidationError{Err: err}
+		}
+	case *Tin:
+		for idx := 0; idx < g.Len(); idx++ {
+			if err := validateTriangle(g.Triangle(idx)); err != nil {
+				return nested(err, "triangle", idx)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateMultiLine(ml geo.MultiLine, layout geo.Layout) error {
+	for idx := 0; idx < ml.Len(); idx++ {
+		if err := validateMultiPoint(ml.Line(idx), layout); err != nil {
+			return nested(err, "line", idx)
+		}
+	}
+
+	return nil
+}
+
+// validateMultiPolygon validates polygons of geometry of type typ
+// (MultiPolygon, PolyhedralSurface or Tin)
+func validateMultiPolygon(mp geo.MultiPolygon, layout geo.Layout, typ uint32) error {
+	c := containers[typ]
+	for idx := 0; idx < mp.Len(); idx++ {
+		var err error
+		if c.types[0] == TriangleType {
+			err = validateTrianglePoints(mp.Polygon(idx), layout)
+		} else {
+			err = validatePolygon(mp.Polygon(idx), layout)
+		}
+		if err != nil {
+			return nested(err, c.name, idx)
+		}
+	}
+
+	return nil
+}
+
+// validateMemberList validates members of geometry of type typ.
+// Members must be of types, allowed by container, have the same
+// dimensions as geometry, and may have SRID only if it equals
+// SRID of geometry, as it is checked by decoding
+func validateMemberList(base Base, geoms []Geometry, typ uint32, membersOnly bool) error {
+	c := containers[typ]
+	parent := baseHeader(base)
+	for idx, geom := range geoms {
+		if geom == nil {
+			return nested(ErrNilMember, c.name, idx)
+		}
+
+		err := c.checkType(geom.Type())
+		if err == nil {
+			err = c.checkMember(parent, baseHeader(geom))
+		}
+		if err == nil {
+			err = validate(geom, membersOnly)
+		}
+		if err != nil {
+			return nested(err, c.name, idx)
+		}
+	}
+
+	return nil
+}
+
+// baseHeader returns header with dimensions and SRID of geometry base
+func baseHeader(b Base) header {
+	return header{wkbType: getFlags(b.HasZ(), b.HasM(), b.HasSRID()), srid: b.SRID()}
+}
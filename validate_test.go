@@ -0,0 +1,134 @@
+package ewkb
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kcasctiv/go-ewkb/geo"
+)
+
+func TestValidate(t *testing.T) {
+	b := NewBase(NDR, false, false, false, 0)
+	bzm := NewBase(NDR, true, true, false, 0)
+	line := geo.NewMultiPoint([]geo.Point{geo.NewPoint(0, 0), geo.NewPoint(1, 1)})
+	lineZ := geo.NewMultiPoint([]geo.Point{geo.NewPointZ(0, 0, 1), geo.NewPointZ(1, 1, 1)})
+	mixed := geo.NewMultiPoint([]geo.Point{geo.NewPoint(0, 0), geo.NewPointZ(1, 1, 1)})
+	ring := geo.NewMultiPoint([]geo.Point{
+		geo.NewPoint(0, 0), geo.NewPoint(0, 1), geo.NewPoint(1, 1), geo.NewPoint(0, 0),
+	})
+	ringZM := geo.NewMultiPoint([]geo.Point{
+		geo.NewPointZM(0, 0, 1, 2), geo.NewPointZM(0, 1, 1, 2),
+		geo.NewPointZM(1, 1, 1, 2), geo.NewPointZM(0, 0, 1, 2),
+	})
+	var (
+		point           = NewPoint(b, geo.NewPoint(1, 2))
+		pointZM         = NewPoint(bzm, geo.NewPoint(1, 2))
+		emptyPointZM    = NewEmptyPoint(bzm)
+		lineString      = NewLineString(b, line)
+		lineStringZ     = NewLineString(b, lineZ)
+		sequence        = NewLineString(b, geo.NewSequence([]float64{0, 0, 1, 1, 1, 1}, true, false))
+		polygon         = NewPolygon(bzm, geo.NewPolygon([]geo.MultiPoint{ringZM, ring}))
+		multiLine       = NewMultiLineString(b, geo.NewMultiLine([]geo.MultiPoint{line, mixed}))
+		triangle        = NewTriangle(bzm, geo.NewPolygon([]geo.MultiPoint{ringZM}))
+		invalidTriangle = NewTriangle(b, geo.NewPolygon([]geo.MultiPoint{line}))
+		tin             = NewTin(b, geo.NewMultiPolygon([]geo.Polygon{
+			geo.NewPolygon([]geo.MultiPoint{ring}),
+			geo.NewPolygon([]geo.MultiPoint{ringZM}),
+		}))
+		collection = NewGeometryCollection(b, []Geometry{&point, &lineString})
+		nested     = NewGeometryCollection(b, []Geometry{&point, &collection, &pointZM})
+		deep       = NewGeometryCollection(b, []Geometry{&collection, &multiLine})
+	)
+	cases := []struct {
+		name string
+		geom Geometry
+		err  error
+		msg  string
+	}{
+		{"point", &point, nil, ""},
+		{"point ZM from XY", &pointZM, ErrDimensionMismatch, "ewkb: dimensions mismatch: expected XYZM, got XY"},
+		{"empty point ZM", &emptyPointZM, nil, ""},
+		{"line string", &lineString, nil, ""},
+		{"line string Z", &lineStringZ, ErrDimensionMismatch, "ewkb: dimensions mismatch: expected XY, got XYZ (point 0)"},
+		{"sequence", &sequence, ErrDimensionMismatch, "ewkb: dimensions mismatch: expected XY, got XYZ"},
+		{"polygon", &polygon, ErrDimensionMismatch, "ewkb: dimensions mismatch: expected XYZM, got XY (ring 1, point 0)"},
+		{"multi line", &multiLine, ErrDimensionMismatch, "ewkb: dimensions mismatch: expected XY, got XYZ (line 1, point 1)"},
+		{"triangle", &triangle, nil, ""},
+		{"invalid triangle", &invalidTriangle, ErrInvalidTriangle, "ewkb: triangle must have four closed points"},
+		{"tin", &tin, ErrDimensionMismatch, "ewkb: dimensions mismatch: expected XY, got XYZM (triangle 1, ring 0, point 0)"},
+		{"collection", &collection, nil, ""},
+		{"nested", &nested, ErrDimensionMismatch, "ewkb: dimensions mismatch: expected XY, got XYZM (geometry 2)"},
+		{"deep", &deep, ErrDimensionMismatch, "ewkb: dimensions mismatch: expected XY, got XYZ (geometry 1, line 1, point 1)"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := c.geom.Validate()
+			if c.err == nil {
+				if err != nil {
+					t.Fatalf("Expected: no errors, got error: %v\n", err)
+				}
+				return
+			}
+
+			if !errors.Is(err, c.err) {
+				t.Fatalf("Expected %v, got %v\n", c.err, err)
+			}
+
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("Expected %T, got %T\n", verr, err)
+			}
+
+			if err.Error() != c.msg {
+				t.Errorf("Expected %q, got %q\n", c.msg, err.Error())
+			}
+		})
+	}
+}
+
+func TestValidate_Decoded(t *testing.T) {
+	cases := []string{
+		"POINT(1 2)",
+		"POINT Z (1 2 3)",
+		"POINTM(1 2 3)",
+		"LINESTRING(0 0,1 1)",
+		"POLYGON Z ((0 0 1,0 1 1,1 1 1,0 0 1))",
+		"MULTIPOINT(1 2,EMPTY)",
+		"MULTILINESTRINGM((0 0 1,1 1 1))",
+		"MULTIPOLYGON(((0 0,0 1,1 1,0 0)))",
+		"GEOMETRYCOLLECTION(POINT(1 2),LINESTRING(0 0,1 1))",
+		"COMPOUNDCURVE(CIRCULARSTRING(0 0,1 1,2 0),(2 0,3 0))",
+		"CURVEPOLYGON(CIRCULARSTRING(0 0,1 1,2 0,1 -1,0 0))",
+		"MULTISURFACE(((0 0,0 1,1 1,0 0)))",
+		"TIN(((0 0,0 1,1 1,0 0)))",
+		"POLYHEDRALSURFACE(((0 0,0 1,1 1,0 0)))",
+		"TRIANGLE((0 0,0 1,1 1,0 0))",
+	}
+
+	for _, c := range cases {
+		t.Run(c, func(t *testing.T) {
+			g, err := ParseEWKT(c)
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			if err = g.Validate(); err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			data, err := g.MarshalBinary()
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			if g, err = Unmarshal(data, DecodeOptions{}); err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			if err = g.Validate(); err != nil {
+				t.Errorf("Expected: no errors, got error: %v\n", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,461 @@
+package ewkb
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/kcasctiv/go-ewkb/geo"
+)
+
+// View presents read-only view of geometry in binary representation.
+// Data is validated once, when view is created, and is not copied,
+// so it must not be modified while view is in use.
+// Coordinates are decoded on demand straight from data.
+// Offsets of rings and members are recorded, when view is created,
+// so they are accessed by index in constant time
+type View struct {
+	header
+	order   binary.ByteOrder
+	body    []byte
+	opts    DecodeOptions
+	depth   int
+	offsets []int
+}
+
+// NewView returns view of geometry, located in data,
+// which is validated with specified options
+func NewView(data []byte, opts DecodeOptions) (View, error) {
+	r := reader{opts: opts, data: data}
+	if opts.MaxInputSize > 0 && len(data) > opts.MaxInputSize {
+		return View{}, r.error(data, 0, ErrLimitExceeded)
+	}
+
+	h, byteOrder, offset, err := r.readHeader(data)
+	if err != nil {
+		return View{}, err
+	}
+
+	n, err := r.skipGeometry(h, data[offset:], byteOrder, 0)
+	if err != nil {
+		return View{}, err
+	}
+
+	if offset+n != len(data) {
+		return View{}, r.error(data, offset+n, ErrTrailingBytes)
+	}
+
+	return *newView(h, byteOrder, data[offset:offset+n], opts, 0), nil
+}
+
+// newView returns view of validated body of geometry
+// and records offsets of its rings or members
+func newView(h header, byteOrder binary.ByteOrder, body []byte, opts DecodeOptions, depth int) *View {
+	v := &View{header: h, order: byteOrder, body: body, opts: opts, depth: depth}
+	switch {
+	case h.Type() == PolygonType || h.Type() == TriangleType:
+		v.offsets = make([]int, v.Len())
+		size := pointSize(h.HasZ(), h.HasM())
+		offset := 4
+		for idx := range v.offsets {
+			v.offsets[idx] = offset
+			offset += 4 + int(byteOrder.Uint32(body[offset:]))*size
+		}
+	case v.legacy():
+		if h.Type() == MultiPolygonType {
+			v.offsets = make([]int, v.Len())
+			r := reader{opts: opts}
+			offset := 4
+			for idx := range v.offsets {
+				v.offsets[idx] = offset
+				n, _ := r.skipPolygon(body[offset:], byteOrder, h)
+				offset += n
+			}
+		}
+	default:
+		if _, ok := containers[h.Type()]; !ok {
+			break
+		}
+
+		v.offsets = make([]int, v.Len())
+		r := reader{opts: opts}
+		offset := 4
+		for idx := range v.offsets {
+			v.offsets[idx] = offset
+			mh, bo, n, _ := readHeader(body[offset:])
+			offset += n
+			n, _ = r.skipGeometry(mh, body[offset:], bo, v.memberDepth())
+			offset += n
+		}
+	}
+
+	return v
+}
+
+// Len returns count of points of LineString, CircularString
+// and MultiPoint, count of rings of Polygon and Triangle, count of members
+// of other geometry objects. For Point it returns 1
+func (v *View) Len() int {
+	if v.Type() == PointType {
+		return 1
+	}
+
+	return int(v.order.Uint32(v.body))
+}
+
+// Point returns point with specified index of Point,
+// LineString, CircularString or MultiPoint. Panics for other types
+// or if index is out of range
+func (v *View) Point(idx int) geo.Point {
+	switch v.Type() {
+	case PointType:
+		checkViewIndex(idx, 1)
+		p, _, _ := getReadPointFunc(v.wkbType)(v.body, v.order)
+		return p
+	case LineType, CircularStringType:
+		return v.points(v.body).Point(idx)
+	case MultiPointType:
+		if v.opts.LegacyMulti {
+			return v.points(v.body).Point(idx)
+		}
+
+		checkViewIndex(idx, v.Len())
+		h, byteOrder, n, _ := readHeader(v.body[v.offsets[idx]:])
+		p, _, _ := getReadPointFunc(h.wkbType)(v.body[v.offsets[idx]+n:], byteOrder)
+		return p
+	default:
+		panic("ewkb: view has no points")
+	}
+}
+
+// Ring returns ring with specified index of Polygon or Triangle,
+// points of which are decoded on demand.
+// Panics for other types or if index is out of range
+func (v *View) Ring(idx int) geo.MultiPoint {
+	if v.Type() != PolygonType && v.Type() != TriangleType {
+		panic("ewkb: view has no rings")
+	}
+
+	checkViewIndex(idx, v.Len())
+	return v.points(v.body[v.offsets[idx]:])
+}
+
+// Part returns view of member with specified index of MultiPoint,
+// MultiLineString, MultiPolygon, GeometryCollection, CompoundCurve,
+// CurvePolygon, MultiCurve, MultiSurface, PolyhedralSurface or Tin,
+// so that calls on member can be chained.
+// Panics for other types or if index is out of range
+func (v *View) Part(idx int) *View {
+	c, ok := containers[v.Type()]
+	if !ok {
+		panic("ewkb: view has no parts")
+	}
+
+	checkViewIndex(idx, v.Len())
+	if v.legacy() && v.Type() == MultiPointType {
+		h := header{byteOrder: v.byteOrder, wkbType: v.wkbType&(zFlag|mFlag) | c.types[0]}
+		size := pointSize(v.HasZ(), v.HasM())
+		return newView(h, v.order, v.body[4+idx*size:4+(idx+1)*size], v.opts, v.depth)
+	}
+
+	end := len(v.body)
+	if idx+1 < len(v.offsets) {
+		end = v.offsets[idx+1]
+	}
+
+	offset := v.offsets[idx]
+	if v.legacy() {
+		h := header{byteOrder: v.byteOrder, wkbType: v.wkbType&(zFlag|mFlag) | c.types[0]}
+		return newView(h, v.order, v.body[offset:end], v.opts, v.memberDepth())
+	}
+
+	h, byteOrder, n, _ := readHeader(v.body[offset:])
+	return newView(h, byteOrder, v.body[offset+n:end], v.opts, v.memberDepth())
+}
+
+// Bounds returns bounding box of all points of viewed geometry,
+// which is computed straight from data without decoding of geometry.
+// NaN coordinates are ignored. Z bounds of geometry without Z dimension
+// are zero. Bounding box of empty geometry is zero
+func (v *View) Bounds() Box3D {
+	inf := math.Inf(1)
+	b := Box3D{inf, inf, inf, -inf, -inf, -inf}
+	v.extendBox(&b, v.header, v.body, v.order)
+	b.finish()
+	return b
+}
+
+// extendBox extends bounds of box with points of geometry with header h,
+// located at the beginning of b, and returns size of its body
+func (v *View) extendBox(box *Box3D, h header, b []byte, byteOrder binary.ByteOrder) int {
+	offset := 0
+	count := func() int {
+		n := int(byteOrder.Uint32(b[offset:]))
+		offset += 4
+		return n
+	}
+
+	size := pointSize(h.HasZ(), h.HasM())
+	coords := func(n int) {
+		for end := offset + n*size; offset < end; offset += size {
+			x := math.Float64frombits(byteOrder.Uint64(b[offset:]))
+			y := math.Float64frombits(byteOrder.Uint64(b[offset+8:]))
+			z := math.NaN()
+			if h.HasZ() {
+				z = math.Float64frombits(byteOrder.Uint64(b[offset+16:]))
+			}
+			box.extend(x, y, z)
+		}
+	}
+
+	switch h.Type() {
+	case PointType:
+		coords(1)
+	case LineType, CircularStringType:
+		coords(count())
+	case PolygonType, TriangleType:
+		for rings := count(); rings > 0; rings-- {
+			coords(count())
+		}
+	case MultiPointType, MultiPolygonType:
+		if !v.opts.LegacyMulti {
+			offset += v.extendMembers(box, b, byteOrder)
+			break
+		}
+
+		if h.Type() == MultiPointType {
+			coords(count())
+			break
+		}
+		for pols := count(); pols > 0; pols-- {
+			for rings := count(); rings > 0; rings-- {
+				coords(count())
+			}
+		}
+	default:
+		offset += v.extendMembers(box, b, byteOrder)
+	}
+
+	return offset
+}
+
+// extendMembers extends bounds of box with points of members,
+// every one of which is preceded by its own header,
+// and returns their size with count
+func (v *View) extendMembers(box *Box3D, b []byte, byteOrder binary.ByteOrder) int {
+	offset := 4
+	for count := int(byteOrder.Uint32(b)); count > 0; count-- {
+		h, bo, n, _ := readHeader(b[offset:])
+		offset += n
+		offset += v.extendBox(box, h, b[offset:], bo)
+	}
+
+	return offset
+}
+
+// legacy reports whether members of viewed geometry are in legacy layout
+func (v *View) legacy() bool {
+	return v.opts.LegacyMulti &&
+		(v.Type() == MultiPointType || v.Type() == MultiPolygonType)
+}
+
+// memberDepth returns depth of members of viewed geometry
+func (v *View) memberDepth() int {
+	if v.Type() == CollectionType {
+		return v.depth + 1
+	}
+
+	return v.depth
+}
+
+// Geometry decodes viewed geometry
+func (v *View) Geometry() (Geometry, error) {
+	r := reader{opts: v.opts, data: v.body}
+	g, _, err := r.readGeometry(v.header, v.body, v.order, v.depth)
+	return g, err
+}
+
+// points returns points, located at the beginning of b
+func (v *View) points(b []byte) geo.MultiPoint {
+	return viewPoints{
+		b:         b,
+		byteOrder: v.order,
+		readFunc:  getReadPointFunc(v.wkbType),
+		size:      pointSize(v.HasZ(), v.HasM()),
+	}
+}
+
+func checkViewIndex(idx, l int) {
+	if idx < 0 || idx >= l {
+		panic("ewkb: view index out of range")
+	}
+}
+
+// viewPoints presents points, decoded on demand from binary data,
+// which starts with count of points
+type viewPoints struct {
+	b         []byte
+	byteOrder binary.ByteOrder
+	readFunc  readPointFunc
+	size      int
+}
+
+func (p viewPoints) Len() int { return int(p.byteOrder.Uint32(p.b)) }
+
+func (p viewPoints) Point(idx int) geo.Point {
+	checkViewIndex(idx, p.Len())
+	pt, _, _ := p.readFunc(p.b[4+idx*p.size:], p.byteOrder)
+	return pt
+}
+
+// skipGeometry validates body of geometry with specified header
+// like readGeometry does, but does not decode coordinates,
+// and returns size of body
+func (r *reader) skipGeometry(
+	h header, b []byte, byteOrder binary.ByteOrder, depth int,
+) (int, error) {
+	switch h.Type() {
+	case PointType:
+		if err := r.addPoints(1); err != nil {
+			return 0, r.error(b, 0, err)
+		}
+
+		if len(b) < pointSize(h.HasZ(), h.HasM()) {
+			return 0, r.error(b, 0, ErrTruncated)
+		}
+
+		return pointSize(h.HasZ(), h.HasM()), nil
+	case LineType:
+		return r.skipPoints(b, byteOrder, h)
+	case PolygonType:
+		return r.skipPolygon(b, byteOrder, h)
+	case MultiPointType:
+		if r.opts.LegacyMulti {
+			return r.skipPoints(b, byteOrder, h)
+		}
+		return r.skipMembers(h, b, byteOrder, MultiPointType, depth)
+	case MultiLineType:
+		return r.skipMembers(h, b, byteOrder, MultiLineType, depth)
+	case MultiPolygonType:
+		if !r.opts.LegacyMulti {
+			return r.skipMembers(h, b, byteOrder, MultiPolygonType, depth)
+		}
+
+		count, err := r.readCount(b, byteOrder, r.addParts)
+		if err != nil {
+			return 0, err
+		}
+
+		offset := 4
+		r.enter("polygon")
+		for idx := 0; idx < count; idx++ {
+			r.at(idx)
+			n, err := r.skipPolygon(b[offset:], byteOrder, h)
+			if err != nil {
+				return 0, err
+			}
+			offset += n
+		}
+		r.leave()
+
+		return offset, nil
+	case CollectionType:
+		if depth+1 > r.opts.maxDepth() {
+			return 0, r.error(b, 0, ErrLimitExceeded)
+		}
+		return r.skipMembers(h, b, byteOrder, CollectionType, depth+1)
+	case CircularStringType:
+		return r.skipPoints(b, byteOrder, h)
+	case CompoundCurveType, CurvePolygonType, MultiCurveType, MultiSurfaceType,
+		PolyhedralSurfaceType, TinType:
+		return r.skipMembers(h, b, byteOrder, h.Type(), depth)
+	case TriangleType:
+		// triangle is small, so it is decoded to be validated
+		_, n, err := r.readTriangle(b, byteOrder, h.wkbType)
+		return n, err
+	default:
+		return 0, r.error(b, 0, ErrUnknownType)
+	}
+}
+
+// skipPoints validates points with dimensions of h
+// and returns their size with count
+func (r *reader) skipPoints(b []byte, byteOrder binary.ByteOrder, h header) (int, error) {
+	size := pointSize(h.HasZ(), h.HasM())
+	count, err := r.readCount(b, byteOrder, r.addPoints)
+	if err != nil {
+		return 0, err
+	}
+	if n := len(b) - 4; count > n/size {
+		r.enter("point")
+		r.at(n / size)
+		err = r.error(b, 4+n/size*size, ErrTruncated)
+		r.leave()
+		return 0, err
+	}
+
+	return 4 + count*size, nil
+}
+
+func (r *reader) skipPolygon(b []byte, byteOrder binary.ByteOrder, h header) (int, error) {
+	count, err := r.readCount(b, byteOrder, r.addRings)
+	if err != nil {
+		return 0, err
+	}
+
+	offset := 4
+	r.enter("ring")
+	for idx := 0; idx < count; idx++ {
+		r.at(idx)
+		n, err := r.skipPoints(b[offset:], byteOrder, h)
+		if err != nil {
+			return 0, err
+		}
+		offset += n
+	}
+	r.leave()
+
+	return offset, nil
+}
+
+// skipMembers validates members of geometry of type typ,
+// every one of which is preceded by its own header
+func (r *reader) skipMembers(
+	parent header, b []byte, byteOrder binary.ByteOrder, typ uint32, depth int,
+) (int, error) {
+	c := containers[typ]
+	count, err := r.readCount(b, byteOrder, r.addParts)
+	if err != nil {
+		return 0, err
+	}
+
+	offset := 4
+	r.enter(c.name)
+	for idx := 0; idx < count; idx++ {
+		r.at(idx)
+		h, bo, n, err := r.readHeader(b[offset:])
+		if err != nil {
+			return 0, err
+		}
+		if err = c.checkType(h.Type()); err != nil {
+			return 0, r.error(b, offset, err)
+		}
+		if err = c.checkMember(parent, h); err != nil {
+			return 0, r.error(b, offset, err)
+		}
+		if !c.strict {
+			if err = checkDims(parent, h); err != nil {
+				return 0, r.error(b, offset, err)
+			}
+		}
+		offset += n
+
+		n, err = r.skipGeometry(h, b[offset:], bo, depth)
+		if err != nil {
+			return 0, err
+		}
+		offset += n
+	}
+	r.leave()
+
+	return offset, nil
+}
@@ -0,0 +1,391 @@
+package ewkb
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewView(t *testing.T) {
+	cases := []string{
+		"SRID=4326;POINT(1 2)",
+		"LINESTRING(1 2 3,4 5 6)",
+		"POLYGON((0 0,1 1,1 0,0 0),(2 2,3 3,3 2,2 2))",
+		"MULTIPOINT(1 2,3 4)",
+		"MULTILINESTRING((1 2,3 4),(5 6,7 8))",
+		"MULTIPOLYGON(((0 0,1 1,1 0,0 0)),((2 2,3 3,3 2,2 2)))",
+		"GEOMETRYCOLLECTION(POINT(1 2),GEOMETRYCOLLECTION(LINESTRING(1 2,3 4)))",
+	}
+
+	for _, s := range cases {
+		t.Run(s, func(t *testing.T) {
+			g, err := ParseEWKT(s)
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			data, err := g.MarshalBinary()
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			v, err := NewView(data, DecodeOptions{})
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			if v.Type() != g.Type() || v.SRID() != g.SRID() ||
+				v.HasZ() != g.HasZ() || v.HasM() != g.HasM() {
+				t.Errorf("Expected base of %v, got type %v, SRID %v\n", s, v.Type(), v.SRID())
+			}
+
+			vg, err := v.Geometry()
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			if vg.String() != s {
+				t.Errorf("Expected %q, got %q\n", s, vg.String())
+			}
+		})
+	}
+}
+
+func TestView_Access(t *testing.T) {
+	g, err := ParseEWKT(
+		"GEOMETRYCOLLECTION(POLYGON((0 0,1 1,1 0,0 0),(2 2,3 3,3 2,2 2)),MULTIPOINT(1 2,3 4),LINESTRING(5 6,7 8))",
+	)
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	data, err := g.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	v, err := NewView(data, DecodeOptions{})
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	if l := v.Len(); l != 3 {
+		t.Fatalf("Len: expected 3, got %v\n", l)
+	}
+
+	poly := v.Part(0)
+	if poly.Type() != PolygonType || poly.Len() != 2 {
+		t.Fatalf("Expected polygon with 2 rings, got type %v, len %v\n", poly.Type(), poly.Len())
+	}
+
+	ring := poly.Ring(1)
+	if p := ring.Point(1); ring.Len() != 4 || p.X() != 3 || p.Y() != 3 {
+		t.Errorf("Expected point (3 3) of 4, got (%v %v) of %v\n", p.X(), p.Y(), ring.Len())
+	}
+
+	mp := v.Part(1)
+	if p := mp.Point(1); mp.Len() != 2 || p.X() != 3 || p.Y() != 4 {
+		t.Errorf("Expected point (3 4) of 2, got (%v %v) of %v\n", p.X(), p.Y(), mp.Len())
+	}
+
+	line := v.Part(2)
+	if p := line.Point(0); line.Len() != 2 || p.X() != 5 || p.Y() != 6 {
+		t.Errorf("Expected point (5 6) of 2, got (%v %v) of %v\n", p.X(), p.Y(), line.Len())
+	}
+
+	lg, err := line.Geometry()
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	if s := lg.String(); s != "LINESTRING(5 6,7 8)" {
+		t.Errorf("Expected %q, got %q\n", "LINESTRING(5 6,7 8)", s)
+	}
+}
+
+func TestView_Legacy(t *testing.T) {
+	data := []byte{
+		1, 6, 0, 0, 0, 1, 0, 0, 0, 1, 0, 0, 0, 2, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 240, 63, 0, 0, 0, 0, 0, 0, 0, 64,
+		0, 0, 0, 0, 0, 0, 8, 64, 0, 0, 0, 0, 0, 0, 16, 64,
+	}
+
+	if _, err := NewView(data, DecodeOptions{}); err == nil {
+		t.Fatal("Expected: error, got: no errors\n")
+	}
+
+	v, err := NewView(data, DecodeOptions{LegacyMulti: true})
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	poly := v.Part(0)
+	if p := poly.Ring(0).Point(1); p.X() != 3 || p.Y() != 4 {
+		t.Errorf("Expected point (3 4), got (%v %v)\n", p.X(), p.Y())
+	}
+
+	g, err := poly.Geometry()
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	if s := g.String(); s != "POLYGON((1 2,3 4))" {
+		t.Errorf("Expected %q, got %q\n", "POLYGON((1 2,3 4))", s)
+	}
+
+	if b := v.Bounds(); b != (Box3D{1, 2, 0, 3, 4, 0}) {
+		t.Errorf("Expected %v, got %v\n", Box3D{1, 2, 0, 3, 4, 0}, b)
+	}
+}
+
+func TestNewView_Malformed(t *testing.T) {
+	cases := []struct {
+		name     string
+		data     []byte
+		expected error
+	}{
+		{"empty", []byte{}, ErrTruncated},
+		{"unknown type", []byte{1, 99, 0, 0, 0}, ErrUnknownType},
+		{"huge line", []byte{1, 2, 0, 0, 0, 255, 255, 255, 255}, ErrInvalidCount},
+		{
+			"truncated point",
+			[]byte{1, 2, 0, 0, 128, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+			ErrTruncated,
+		},
+		{
+			"trailing bytes",
+			[]byte{1, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 28, 64, 0, 0, 0, 0, 0, 0, 32, 64, 0},
+			ErrTrailingBytes,
+		},
+		{"too deep", nestedCollectionData, ErrLimitExceeded},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := NewView(c.data, DecodeOptions{MaxDepth: 1})
+			if !errors.Is(err, c.expected) {
+				t.Errorf("Expected %v, got %v\n", c.expected, err)
+			}
+		})
+	}
+}
+
+func TestNewView_TruncatedRing(t *testing.T) {
+	g, err := ParseEWKT("POLYGON((0 0,1 1,1 0,0 0))")
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	data, err := g.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	data = data[:len(data)-20]
+	for name, decode := range map[string]func([]byte) error{
+		"view": func(b []byte) error {
+			_, err := NewView(b, DecodeOptions{})
+			return err
+		},
+		"unmarshal": func(b []byte) error {
+			_, err := Unmarshal(b, DecodeOptions{})
+			return err
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := decode(data)
+			if !errors.Is(err, ErrTruncated) {
+				t.Fatalf("Expected %v, got %v\n", ErrTruncated, err)
+			}
+
+			var de *DecodeError
+			if !errors.As(err, &de) {
+				t.Fatalf("Expected *DecodeError, got %T\n", err)
+			}
+
+			if de.Path != "ring 0, point 2" {
+				t.Errorf("Expected %q, got %q\n", "ring 0, point 2", de.Path)
+			}
+
+			if de.Offset != 45 {
+				t.Errorf("Expected %v, got %v\n", 45, de.Offset)
+			}
+		})
+	}
+}
+
+func TestNewView_TruncatedPolygon(t *testing.T) {
+	g, err := ParseEWKT(
+		"MULTIPOLYGON(((0 0,1 0,1 1,0 0)),((0 0,1 0,1 1,0 0),(0 0,1 0,1 1,0 0)))",
+	)
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	data, err := g.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	cases := []struct {
+		name   string
+		data   []byte
+		offset int
+		path   string
+	}{
+		{"first polygon", data[:20], 18, "polygon 0, ring 0"},
+		{"first ring", data[:30], 22, "polygon 0, ring 0, point 0"},
+		{"second polygon", data[:100], 99, "polygon 1, ring 0, point 0"},
+	}
+
+	for _, c := range cases {
+		for name, decode := range map[string]func([]byte) error{
+			"view": func(b []byte) error {
+				_, err := NewView(b, DecodeOptions{})
+				return err
+			},
+			"unmarshal": func(b []byte) error {
+				_, err := Unmarshal(b, DecodeOptions{})
+				return err
+			},
+		} {
+			t.Run(c.name+"/"+name, func(t *testing.T) {
+				err := decode(c.data)
+				if !errors.Is(err, ErrTruncated) {
+					t.Fatalf("Expected %v, got %v\n", ErrTruncated, err)
+				}
+
+				var de *DecodeError
+				if !errors.As(err, &de) {
+					t.Fatalf("Expected *DecodeError, got %T\n", err)
+				}
+
+				if de.Path != c.path {
+					t.Errorf("Expected %q, got %q\n", c.path, de.Path)
+				}
+
+				if de.Offset != c.offset {
+					t.Errorf("Expected %v, got %v\n", c.offset, de.Offset)
+				}
+			})
+		}
+	}
+}
+
+func TestView_Parts(t *testing.T) {
+	g, err := ParseEWKT(
+		"GEOMETRYCOLLECTION(MULTIPOINT(1 2,3 4,5 6),POINT(7 8),MULTIPOLYGON(((0 0,1 1,1 0,0 0)),((2 2,3 3,3 2,2 2))))",
+	)
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	data, err := g.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	v, err := NewView(data, DecodeOptions{})
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	c := g.(*GeometryCollection)
+	for idx := 0; idx < v.Len(); idx++ {
+		part := v.Part(idx)
+		pg, err := part.Geometry()
+		if err != nil {
+			t.Fatalf("Expected: no errors, got error: %v\n", err)
+		}
+
+		if s := c.Geometry(idx).String(); pg.String() != s {
+			t.Errorf("Expected %q, got %q\n", s, pg.String())
+		}
+	}
+
+	mp := v.Part(0)
+	for idx, expected := range []float64{1, 3, 5} {
+		if p := mp.Point(idx); p.X() != expected {
+			t.Errorf("Expected %v, got %v\n", expected, p.X())
+		}
+	}
+
+	mpol := v.Part(2)
+	pol := mpol.Part(1)
+	if p := pol.Ring(0).Point(1); p.X() != 3 || p.Y() != 3 {
+		t.Errorf("Expected point (3 3), got (%v %v)\n", p.X(), p.Y())
+	}
+}
+
+func TestView_Part_Chained(t *testing.T) {
+	g, err := ParseEWKT("GEOMETRYCOLLECTION(MULTIPOINT(1 2,3 4),MULTIPOLYGON(((0 0,1 1,1 0,0 0)),((2 2,3 3,3 2,2 2))))")
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	data, err := g.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	v, err := NewView(data, DecodeOptions{})
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	if x := v.Part(0).Point(1).X(); x != 3 {
+		t.Errorf("Expected %v, got %v\n", 3, x)
+	}
+
+	if typ := v.Part(1).Part(1).Type(); typ != PolygonType {
+		t.Errorf("Type: expected %v, got %v\n", PolygonType, typ)
+	}
+
+	if y := v.Part(1).Part(1).Ring(0).Point(2).Y(); y != 2 {
+		t.Errorf("Expected %v, got %v\n", 2, y)
+	}
+
+	if l := v.Part(0).Part(1).Len(); l != 1 {
+		t.Errorf("Len: expected %v, got %v\n", 1, l)
+	}
+}
+
+func TestView_Bounds(t *testing.T) {
+	cases := []struct {
+		wkt      string
+		expected Box3D
+	}{
+		{"POINT(1 2)", Box3D{1, 2, 0, 1, 2, 0}},
+		{"POINT EMPTY", Box3D{}},
+		{"LINESTRING(1 2 3,-4 5 6)", Box3D{-4, 2, 3, 1, 5, 6}},
+		{"POLYGON M((0 0 9,1 1 9,1 0 9,0 0 9))", Box3D{0, 0, 0, 1, 1, 0}},
+		{"MULTIPOINT(1 2,EMPTY,3 -4)", Box3D{1, -4, 0, 3, 2, 0}},
+		{
+			"GEOMETRYCOLLECTION Z(POINT Z(1 2 3),MULTIPOLYGON Z(((0 0 0,5 5 1,5 0 1,0 0 0))))",
+			Box3D{0, 0, 0, 5, 5, 3},
+		},
+		{"GEOMETRYCOLLECTION EMPTY", Box3D{}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.wkt, func(t *testing.T) {
+			g, err := ParseEWKT(c.wkt)
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			data, err := g.MarshalBinary()
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			v, err := NewView(data, DecodeOptions{})
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			if b := v.Bounds(); b != c.expected {
+				t.Errorf("Expected %v, got %v\n", c.expected, b)
+			}
+		})
+	}
+}
@@ -0,0 +1,761 @@
+package ewkb
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kcasctiv/go-ewkb/geo"
+)
+
+// ParseError presents error of WKT/EWKT parsing
+type ParseError struct {
+	// Column is 1-based position of the offending character
+	Column int
+	// Msg describes the problem
+	Msg string
+	// Err is underlying error, e.g. ErrLimitExceeded, if any
+	Err error
+}
+
+// Error implements error interface
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("wkt: %s at column %d", e.Msg, e.Column)
+}
+
+// Unwrap returns underlying error
+func (e *ParseError) Unwrap() error { return e.Err }
+
+// ParseEWKT parses EWKT (or plain WKT) geometry representation,
+// as produced by String methods of geometry objects.
+// Returned geometry has NDR byte order
+func ParseEWKT(s string) (Geometry, error) {
+	return parseEWKT(s, DecodeOptions{})
+}
+
+// parseEWKT parses EWKT geometry representation, checking
+// limits of decoding options while parsing.
+// MaxInputSize limits length of s
+func parseEWKT(s string, opts DecodeOptions) (Geometry, error) {
+	p := wktParser{s: s, lim: reader{opts: opts}}
+	if opts.MaxInputSize > 0 && len(s) > opts.MaxInputSize {
+		return nil, p.limitError(0, "input size exceeds %d", opts.MaxInputSize)
+	}
+
+	return p.parse(true)
+}
+
+// ParseWKT parses WKT geometry representation.
+// Unlike ParseEWKT, it does not accept SRID prefix.
+// Returned geometry has NDR byte order
+func ParseWKT(s string) (Geometry, error) {
+	p := wktParser{s: s}
+	return p.parse(false)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface.
+// Accepts WKT/EWKT geometry representation.
+// Limits of options of wrapper are checked while parsing
+func (w *Wrapper) UnmarshalText(text []byte) error {
+	var err error
+	w.Geometry, err = parseEWKT(string(text), w.Options)
+	return err
+}
+
+type wktParser struct {
+	s   string
+	pos int
+	// depth is nesting depth of currently parsed geometry collection
+	depth int
+	// lim counts points, rings and parts to check them
+	// against limits of decoding options
+	lim reader
+}
+
+func (p *wktParser) errorf(pos int, format string, args ...interface{}) error {
+	return &ParseError{Column: pos + 1, Msg: fmt.Sprintf(format, args...)}
+}
+
+// limitError returns ParseError, which matches ErrLimitExceeded
+func (p *wktParser) limitError(pos int, format string, args ...interface{}) error {
+	return &ParseError{Column: pos + 1, Msg: fmt.Sprintf(format, args...), Err: ErrLimitExceeded}
+}
+
+// add adds element to count of elements with add func,
+// returning ParseError at current position, if limit is exceeded
+func (p *wktParser) add(add func(int) error, name string) error {
+	if add(1) != nil {
+		return p.limitError(p.pos, "count of %s exceeds limit", name)
+	}
+
+	return nil
+}
+
+func (p *wktParser) skipSpace() {
+	for p.pos < len(p.s) {
+		switch p.s[p.pos] {
+		case ' ', '\t', '\n', '\r':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (p *wktParser) peek() byte {
+	p.skipSpace()
+	if p.pos >= len(p.s) {
+		return 0
+	}
+
+	return p.s[p.pos]
+}
+
+func (p *wktParser) expect(c byte) error {
+	if p.peek() != c {
+		return p.unexpected(fmt.Sprintf("%q", c))
+	}
+
+	p.pos++
+	return nil
+}
+
+func (p *wktParser) unexpected(expected string) error {
+	if p.pos >= len(p.s) {
+		return p.errorf(p.pos, "unexpected end of input, expected %s", expected)
+	}
+
+	return p.errorf(p.pos, "unexpected %q, expected %s", p.s[p.pos], expected)
+}
+
+// word reads next alphabetic word and returns it in upper case
+func (p *wktParser) word() (string, int) {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.s) && isWKTLetter(p.s[p.pos]) {
+		p.pos++
+	}
+
+	return strings.ToUpper(p.s[start:p.pos]), start
+}
+
+// peekWord returns next alphabetic word in upper case without consuming it
+func (p *wktParser) peekWord() string {
+	pos := p.pos
+	w, _ := p.word()
+	p.pos = pos
+	return w
+}
+
+func isWKTLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func (p *wktParser) parse(allowSRID bool) (Geometry, error) {
+	var hasSRID bool
+	var srid int32
+	if p.peekWord() == "SRID" {
+		start := p.pos
+		if !allowSRID {
+			return nil, p.errorf(start, "unexpected SRID prefix")
+		}
+
+		p.word()
+		if err := p.expect('='); err != nil {
+			return nil, err
+		}
+
+		p.skipSpace()
+		numStart := p.pos
+		for p.pos < len(p.s) && p.s[p.pos] != ';' {
+			p.pos++
+		}
+
+		v, err := strconv.ParseInt(strings.TrimSpace(p.s[numStart:p.pos]), 10, 32)
+		if err != nil {
+			return nil, p.errorf(numStart, "invalid SRID")
+		}
+
+		if err := p.expect(';'); err != nil {
+			return nil, err
+		}
+
+		hasSRID = true
+		srid = int32(v)
+	}
+
+	g, err := p.parseGeometry(hasSRID, srid, coordDims{})
+	if err != nil {
+		return nil, err
+	}
+
+	if p.peek() != 0 {
+		return nil, p.errorf(p.pos, "unexpected trailing characters")
+	}
+
+	return g, nil
+}
+
+var wktTypes = []struct {
+	name string
+	typ  uint32
+}{
+	// Longer names go first, so that prefixes do not shadow them
+	{"GEOMETRYCOLLECTION", CollectionType},
+	{"POLYHEDRALSURFACE", PolyhedralSurfaceType},
+	{"MULTILINESTRING", MultiLineType},
+	{"CIRCULARSTRING", CircularStringType},
+	{"COMPOUNDCURVE", CompoundCurveType},
+	{"MULTIPOLYGON", MultiPolygonType},
+	{"MULTISURFACE", MultiSurfaceType},
+	{"CURVEPOLYGON", CurvePolygonType},
+	{"MULTIPOINT", MultiPointType},
+	{"MULTICURVE", MultiCurveType},
+	{"LINESTRING", LineType},
+	{"TRIANGLE", TriangleType},
+	{"POLYGON", PolygonType},
+	{"POINT", PointType},
+	{"TIN", TinType},
+}
+
+// sridPrefix returns EWKT prefix with SRID of geometry,
+// or empty string, if geometry has no SRID
+func sridPrefix(b Base) string {
+	if !b.HasSRID() {
+		return ""
+	}
+
+	return "SRID=" + strconv.FormatInt(int64(b.SRID()), 10) + ";"
+}
+
+// wktDims returns dimensions suffix of WKT type name of geometry.
+// M is always specified, as it can not be inferred from count
+// of coordinate values. Z is specified only for empty geometry,
+// which has no coordinate values to infer it from
+func wktDims(g Geometry) string {
+	switch {
+	case !g.HasZ() && g.HasM():
+		return "M"
+	case !g.HasZ() || !g.IsEmpty():
+		return ""
+	case g.HasM():
+		return " ZM"
+	default:
+		return " Z"
+	}
+}
+
+// wktTypeName returns WKT name of geometry type
+func wktTypeName(typ uint32) string {
+	for _, t := range wktTypes {
+		if t.typ == typ {
+			return t.name
+		}
+	}
+
+	return ""
+}
+
+// parseTag parses geometry type name with optional dimensions suffix
+func (p *wktParser) parseTag(parent coordDims) (uint32, coordDims, error) {
+	w, start := p.word()
+	if w == "" {
+		return 0, coordDims{}, p.unexpected("geometry type")
+	}
+
+	var typ uint32
+	var suffix string
+	for _, t := range wktTypes {
+		if strings.HasPrefix(w, t.name) {
+			typ = t.typ
+			suffix = w[len(t.name):]
+			break
+		}
+	}
+	if typ == 0 {
+		return 0, coordDims{}, p.errorf(start, "unknown geometry type %q", w)
+	}
+
+	if suffix == "" {
+		switch p.peekWord() {
+		case "Z", "M", "ZM":
+			suffix, _ = p.word()
+		}
+	}
+
+	dims := parent
+	switch suffix {
+	case "":
+	case "Z":
+		dims = coordDims{known: true, z: true}
+	case "M":
+		dims = coordDims{known: true, m: true}
+	case "ZM":
+		dims = coordDims{known: true, z: true, m: true}
+	default:
+		return 0, coordDims{}, p.errorf(start, "unknown geometry type %q", w)
+	}
+
+	// Member can not override dimensions, already fixed by parent
+	if parent.known && dims != parent {
+		return 0, coordDims{}, p.errorf(start, "%s dimensions of %s differ from %s dimensions of parent",
+			geo.NewLayout(dims.z, dims.m), wktTypeName(typ), geo.NewLayout(parent.z, parent.m))
+	}
+
+	return typ, dims, nil
+}
+
+// isEmpty consumes EMPTY keyword, if it is next
+func (p *wktParser) isEmpty() bool {
+	if p.peekWord() == "EMPTY" {
+		p.word()
+		return true
+	}
+
+	return false
+}
+
+func (p *wktParser) parseGeometry(hasSRID bool, srid int32, parent coordDims) (Geometry, error) {
+	typ, dims, err := p.parseTag(parent)
+	if err != nil {
+		return nil, err
+	}
+
+	switch typ {
+	case PointType:
+		if err := p.add(p.lim.addPoints, "points"); err != nil {
+			return nil, err
+		}
+
+		var pt geo.Point
+		if p.isEmpty() {
+			pt = dims.emptyPoint()
+		} else {
+			if err := p.expect('('); err != nil {
+				return nil, err
+			}
+			if pt, err = p.parseCoord(&dims); err != nil {
+				return nil, err
+			}
+			if err := p.expect(')'); err != nil {
+				return nil, err
+			}
+		}
+
+		g := NewPoint(NewBase(NDR, dims.z, dims.m, hasSRID, srid), pt)
+		return &g, nil
+	case LineType:
+		mp, err := p.parseCoords(&dims)
+		if err != nil {
+			return nil, err
+		}
+
+		g := NewLineString(NewBase(NDR, dims.z, dims.m, hasSRID, srid), mp)
+		return &g, nil
+	case PolygonType:
+		poly, err := p.parsePolygon(&dims)
+		if err != nil {
+			return nil, err
+		}
+
+		g := NewPolygon(NewBase(NDR, dims.z, dims.m, hasSRID, srid), poly)
+		return &g, nil
+	case MultiPointType:
+		mp, err := p.parseMultiPoint(&dims)
+		if err != nil {
+			return nil, err
+		}
+
+		g := NewMultiPoint(NewBase(NDR, dims.z, dims.m, hasSRID, srid), mp)
+		return &g, nil
+	case MultiLineType:
+		var lines []geo.MultiPoint
+		err := p.parseList(func() error {
+			if err := p.add(p.lim.addParts, "parts"); err != nil {
+				return err
+			}
+			line, err := p.parseCoords(&dims)
+			lines = append(lines, line)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		g := NewMultiLineString(NewBase(NDR, dims.z, dims.m, hasSRID, srid), geo.NewMultiLine(lines))
+		return &g, nil
+	case MultiPolygonType:
+		var pols []geo.Polygon
+		err := p.parseList(func() error {
+			if err := p.add(p.lim.addParts, "parts"); err != nil {
+				return err
+			}
+			poly, err := p.parsePolygon(&dims)
+			pols = append(pols, poly)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		g := NewMultiPolygon(NewBase(NDR, dims.z, dims.m, hasSRID, srid), geo.NewMultiPolygon(pols))
+		return &g, nil
+	case CircularStringType:
+		mp, err := p.parseCoords(&dims)
+		if err != nil {
+			return nil, err
+		}
+
+		g := NewCircularString(NewBase(NDR, dims.z, dims.m, hasSRID, srid), mp)
+		return &g, nil
+	case CompoundCurveType:
+		curves, err := p.parseMembers(typ, &dims)
+		if err != nil {
+			return nil, err
+		}
+
+		g := NewCompoundCurve(NewBase(NDR, dims.z, dims.m, hasSRID, srid), curves)
+		return &g, nil
+	case CurvePolygonType:
+		rings, err := p.parseMembers(typ, &dims)
+		if err != nil {
+			return nil, err
+		}
+
+		g := NewCurvePolygon(NewBase(NDR, dims.z, dims.m, hasSRID, srid), rings)
+		return &g, nil
+	case MultiCurveType:
+		curves, err := p.parseMembers(typ, &dims)
+		if err != nil {
+			return nil, err
+		}
+
+		g := NewMultiCurve(NewBase(NDR, dims.z, dims.m, hasSRID, srid), curves)
+		return &g, nil
+	case MultiSurfaceType:
+		surfaces, err := p.parseMembers(typ, &dims)
+		if err != nil {
+			return nil, err
+		}
+
+		g := NewMultiSurface(NewBase(NDR, dims.z, dims.m, hasSRID, srid), surfaces)
+		return &g, nil
+	case PolyhedralSurfaceType:
+		var pols []geo.Polygon
+		err := p.parseList(func() error {
+			if err := p.add(p.lim.addParts, "parts"); err != nil {
+				return err
+			}
+			poly, err := p.parsePolygon(&dims)
+			pols = append(pols, poly)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		g := NewPolyhedralSurface(NewBase(NDR, dims.z, dims.m, hasSRID, srid), geo.NewMultiPolygon(pols))
+		return &g, nil
+	case TinType:
+		var pols []geo.Polygon
+		err := p.parseList(func() error {
+			if err := p.add(p.lim.addParts, "parts"); err != nil {
+				return err
+			}
+			poly, err := p.parseTriangle(&dims)
+			pols = append(pols, poly)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		g := NewTin(NewBase(NDR, dims.z, dims.m, hasSRID, srid), geo.NewMultiPolygon(pols))
+		return &g, nil
+	case TriangleType:
+		poly, err := p.parseTriangle(&dims)
+		if err != nil {
+			return nil, err
+		}
+
+		g := NewTriangle(NewBase(NDR, dims.z, dims.m, hasSRID, srid), poly)
+		return &g, nil
+	default:
+		if p.depth++; p.depth > p.lim.opts.maxDepth() {
+			return nil, p.limitError(p.pos, "nesting depth of geometry collections exceeds %d", p.lim.opts.maxDepth())
+		}
+		defer func() { p.depth-- }()
+
+		geoms := []Geometry{}
+		var empties []int
+		err := p.parseList(func() error {
+			if err := p.add(p.lim.addParts, "parts"); err != nil {
+				return err
+			}
+			geom, err := p.parseGeometry(false, 0, dims)
+			if err != nil {
+				return err
+			}
+			empties = dims.resolve(geom, len(geoms), empties)
+			geoms = append(geoms, geom)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		dims.force(geoms, empties)
+
+		g := NewGeometryCollection(NewBase(NDR, dims.z, dims.m, hasSRID, srid), geoms)
+		return &g, nil
+	}
+}
+
+// resolve fixes unknown dimensions by member idx of geometry.
+// Empty member without Z and M may have been parsed with unknown
+// dimensions, so its index is appended to empties instead
+func (d *coordDims) resolve(member Geometry, idx int, empties []int) []int {
+	switch {
+	case d.known:
+	case member.IsEmpty() && !member.HasZ() && !member.HasM():
+		return append(empties, idx)
+	default:
+		*d = coordDims{known: true, z: member.HasZ(), m: member.HasM()}
+	}
+
+	return empties
+}
+
+// force coerces empty members, which were parsed
+// before dimensions became known, to dimensions
+func (d coordDims) force(geoms []Geometry, empties []int) {
+	if !d.known {
+		return
+	}
+
+	f := forcer{hasZ: d.z, hasM: d.m}
+	for _, idx := range empties {
+		geoms[idx] = f.geometry(geoms[idx])
+	}
+}
+
+// parseTriangle parses polygon and checks that it is valid triangle
+func (p *wktParser) parseTriangle(dims *coordDims) (geo.Polygon, error) {
+	p.skipSpace()
+	start := p.pos
+	poly, err := p.parsePolygon(dims)
+	if err != nil {
+		return nil, err
+	}
+
+	if validateTriangle(poly) != nil {
+		return nil, p.errorf(start, "triangle must have four closed points")
+	}
+
+	return poly, nil
+}
+
+// parseMembers parses members of curved geometry of type typ.
+// Members of bare type (LineString or Polygon) are written
+// without type name, so EMPTY member is of bare type too
+func (p *wktParser) parseMembers(typ uint32, dims *coordDims) ([]Geometry, error) {
+	c := containers[typ]
+	geoms := []Geometry{}
+	var empties []int
+	err := p.parseList(func() error {
+		if err := p.add(p.lim.addParts, "parts"); err != nil {
+			return err
+		}
+		var geom Geometry
+		if p.peek() == '(' || p.peekWord() == "EMPTY" {
+			if c.types[0] == PolygonType {
+				poly, err := p.parsePolygon(dims)
+				if err != nil {
+					return err
+				}
+
+				g := NewPolygon(NewBase(NDR, dims.z, dims.m, false, 0), poly)
+				geom = &g
+			} else {
+				mp, err := p.parseCoords(dims)
+				if err != nil {
+					return err
+				}
+
+				g := NewLineString(NewBase(NDR, dims.z, dims.m, false, 0), mp)
+				geom = &g
+			}
+		} else {
+			// Type of member is checked before it is parsed,
+			// so that nesting of curved geometry is bounded
+			start := p.pos
+			mtyp, _, err := p.parseTag(*dims)
+			if err != nil {
+				return err
+			}
+			if c.checkType(mtyp) != nil {
+				return p.errorf(start, "unexpected %s in %s", wktTypeName(mtyp), wktTypeName(typ))
+			}
+
+			p.pos = start
+			if geom, err = p.parseGeometry(false, 0, *dims); err != nil {
+				return err
+			}
+		}
+
+		empties = dims.resolve(geom, len(geoms), empties)
+		geoms = append(geoms, geom)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	dims.force(geoms, empties)
+
+	return geoms, nil
+}
+
+// parseList parses EMPTY keyword or comma separated list
+// of items in brackets, calling item func for every item
+func (p *wktParser) parseList(item func() error) error {
+	if p.isEmpty() {
+		return nil
+	}
+
+	if err := p.expect('('); err != nil {
+		return err
+	}
+
+	for {
+		if err := item(); err != nil {
+			return err
+		}
+
+		if p.peek() != ',' {
+			break
+		}
+		p.pos++
+	}
+
+	return p.expect(')')
+}
+
+func (p *wktParser) parseCoords(dims *coordDims) (geo.MultiPoint, error) {
+	points := []geo.Point{}
+	err := p.parseList(func() error {
+		if err := p.add(p.lim.addPoints, "points"); err != nil {
+			return err
+		}
+		pt, err := p.parseCoord(dims)
+		points = append(points, pt)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return geo.NewMultiPoint(points), nil
+}
+
+func (p *wktParser) parsePolygon(dims *coordDims) (geo.Polygon, error) {
+	rings := []geo.MultiPoint{}
+	err := p.parseList(func() error {
+		if err := p.add(p.lim.addRings, "rings"); err != nil {
+			return err
+		}
+		ring, err := p.parseCoords(dims)
+		rings = append(rings, ring)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return geo.NewPolygon(rings), nil
+}
+
+// parseMultiPoint parses both MULTIPOINT(1 2,3 4)
+// and MULTIPOINT((1 2),(3 4)) forms, which may contain EMPTY points
+func (p *wktParser) parseMultiPoint(dims *coordDims) (geo.MultiPoint, error) {
+	points := []geo.Point{}
+	var empties []int
+	err := p.parseList(func() error {
+		if err := p.add(p.lim.addParts, "parts"); err != nil {
+			return err
+		}
+		if err := p.add(p.lim.addPoints, "points"); err != nil {
+			return err
+		}
+		if p.isEmpty() {
+			empties = append(empties, len(points))
+			points = append(points, nil)
+			return nil
+		}
+
+		bracket := p.peek() == '('
+		if bracket {
+			p.pos++
+		}
+
+		pt, err := p.parseCoord(dims)
+		if err != nil {
+			return err
+		}
+		points = append(points, pt)
+
+		if bracket {
+			return p.expect(')')
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Dimensions may become known after empty points
+	for _, idx := range empties {
+		points[idx] = dims.emptyPoint()
+	}
+
+	return geo.NewMultiPoint(points), nil
+}
+
+// parseCoord parses single coordinate, checking
+// that count of its values conforms to dimensions
+func (p *wktParser) parseCoord(dims *coordDims) (geo.Point, error) {
+	start := p.pos
+	var vals [4]float64
+	n := 0
+	for {
+		c := p.peek()
+		if c == 0 || c == ',' || c == ')' || c == '(' {
+			break
+		}
+
+		if n == len(vals) {
+			return nil, p.errorf(p.pos, "too many coordinate values")
+		}
+
+		numStart := p.pos
+		for p.pos < len(p.s) && !strings.ContainsRune(" \t\n\r,()", rune(p.s[p.pos])) {
+			p.pos++
+		}
+
+		v, err := strconv.ParseFloat(p.s[numStart:p.pos], 64)
+		if err != nil {
+			return nil, p.errorf(numStart, "invalid number %q", p.s[numStart:p.pos])
+		}
+
+		vals[n] = v
+		n++
+	}
+
+	if !dims.known && !dims.infer(n) {
+		return nil, p.errorf(start, "expected 2 to 4 coordinate values, got %d", n)
+	}
+
+	if n != dims.count() {
+		return nil, p.errorf(start, "expected %d coordinate values, got %d", dims.count(), n)
+	}
+
+	return dims.point(vals[:n]), nil
+}
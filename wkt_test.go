@@ -0,0 +1,399 @@
+package ewkb
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/kcasctiv/go-ewkb/geo"
+)
+
+func TestParseEWKT(t *testing.T) {
+	cases := []struct {
+		name     string
+		src      string
+		expected string
+	}{
+		{"point", "POINT(6 5)", "POINT(6 5)"},
+		{"point with Z dimension", "POINT(6 5 4)", "POINT(6 5 4)"},
+		{"point with M dimension", "POINTM(6 5 4)", "POINTM(6 5 4)"},
+		{"point with Z and M dimensions", "POINT(6 5 4 3)", "POINT(6 5 4 3)"},
+		{"point with SRID", "SRID=4321;POINT(6 5)", "SRID=4321;POINT(6 5)"},
+		{"point empty", "POINT EMPTY", "POINT EMPTY"},
+		{"point empty with Z keyword", "POINT Z EMPTY", "POINT Z EMPTY"},
+		{
+			"collection with empty member first",
+			"GEOMETRYCOLLECTION(POINT EMPTY,MULTIPOINT(EMPTY,1 2 3))",
+			"GEOMETRYCOLLECTION(POINT Z EMPTY,MULTIPOINT(EMPTY,1 2 3))",
+		},
+		{"point with Z keyword", "POINT Z (1 2 3)", "POINT(1 2 3)"},
+		{"point with ZM keyword", "point zm(1 2 3 4)", "POINT(1 2 3 4)"},
+		{"point with M keyword", "POINT M (1 2 3)", "POINTM(1 2 3)"},
+		{"point with spaces", "  SRID=4326 ; POINT ( -1.5  2e3 ) ", "SRID=4326;POINT(-1.5 2000)"},
+		{"line", "LINESTRING(1 3,2 4)", "LINESTRING(1 3,2 4)"},
+		{"line with M dimension", "LINESTRINGM(1 3 7,2 4 5)", "LINESTRINGM(1 3 7,2 4 5)"},
+		{"line empty", "LINESTRING EMPTY", "LINESTRING EMPTY"},
+		{
+			"polygon",
+			"POLYGON((1 2,3 4,5 6,1 2),(7 8,9 10,11 12,7 8))",
+			"POLYGON((1 2,3 4,5 6,1 2),(7 8,9 10,11 12,7 8))",
+		},
+		{"polygon empty", "POLYGON EMPTY", "POLYGON EMPTY"},
+		{"multipoint", "MULTIPOINT(1 3,2 4)", "MULTIPOINT(1 3,2 4)"},
+		{"multipoint with brackets", "MULTIPOINT((1 3),(2 4))", "MULTIPOINT(1 3,2 4)"},
+		{"multipoint empty", "MULTIPOINT EMPTY", "MULTIPOINT EMPTY"},
+		{
+			"multiline",
+			"SRID=4326;MULTILINESTRING((1 2 3,4 5 6),(7 8 9,10 11 12))",
+			"SRID=4326;MULTILINESTRING((1 2 3,4 5 6),(7 8 9,10 11 12))",
+		},
+		{"multiline empty", "MULTILINESTRING EMPTY", "MULTILINESTRING EMPTY"},
+		{
+			"multipolygon",
+			"MULTIPOLYGONM(((1 2 3,4 5 6,7 8 9,1 2 3)),((1 2 3,4 5 6,7 8 9,1 2 3)))",
+			"MULTIPOLYGONM(((1 2 3,4 5 6,7 8 9,1 2 3)),((1 2 3,4 5 6,7 8 9,1 2 3)))",
+		},
+		{"multipolygon empty", "MULTIPOLYGON EMPTY", "MULTIPOLYGON EMPTY"},
+		{
+			"collection",
+			"SRID=4326;GEOMETRYCOLLECTION(POINT(1 2),LINESTRING(1 2,3 4))",
+			"SRID=4326;GEOMETRYCOLLECTION(POINT(1 2),LINESTRING(1 2,3 4))",
+		},
+		{
+			"collection with M dimension",
+			"GEOMETRYCOLLECTIONM(POINTM(1 2 3),POINT(4 5 6))",
+			"GEOMETRYCOLLECTIONM(POINTM(1 2 3),POINTM(4 5 6))",
+		},
+		{
+			"nested collection",
+			"GEOMETRYCOLLECTION(POINT(1 2),GEOMETRYCOLLECTION(POINT(3 4)))",
+			"GEOMETRYCOLLECTION(POINT(1 2),GEOMETRYCOLLECTION(POINT(3 4)))",
+		},
+		{"collection empty", "GEOMETRYCOLLECTION EMPTY", "GEOMETRYCOLLECTION EMPTY"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			g, err := ParseEWKT(c.src)
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			if s := g.String(); s != c.expected {
+				t.Errorf("Expected %q, got %q\n", c.expected, s)
+			}
+		})
+	}
+}
+
+func TestParseEWKT_Errors(t *testing.T) {
+	cases := []struct {
+		name   string
+		src    string
+		column int
+	}{
+		{"empty input", "", 1},
+		{"unknown type", "CIRCLE(1 2)", 1},
+		{"unknown dimensions", "POINTQ(1 2)", 1},
+		{"missing bracket", "POINT(1 2", 10},
+		{"invalid number", "POINT(1 x)", 9},
+		{"too few values", "POINT(1)", 7},
+		{"too many values", "POINT(1 2 3 4 5)", 15},
+		{"mixed dimensions", "LINESTRING(1 2,3 4 5)", 16},
+		{"dimensions mismatch keyword", "POINT Z (1 2)", 10},
+		{"member dimensions conflict", "GEOMETRYCOLLECTION Z (POINT M (1 2 3))", 23},
+		{"member dimensions after first", "GEOMETRYCOLLECTION(POINT Z (1 2 3),POINTM(1 2 3))", 36},
+		{"curve member dimensions conflict", "COMPOUNDCURVE Z (CIRCULARSTRING ZM (0 0 0 0,1 1 1 1,2 0 0 0))", 18},
+		{"invalid SRID", "SRID=abc;POINT(1 2)", 6},
+		{"trailing characters", "POINT(1 2) x", 12},
+		{"unexpected character", "POLYGON((1 2,3 4,5 6,1 2)]", 26},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := ParseEWKT(c.src)
+			if err == nil {
+				t.Fatal("Expected: error, got: no errors\n")
+			}
+
+			var perr *ParseError
+			if !errors.As(err, &perr) {
+				t.Fatalf("Expected ParseError, got %T\n", err)
+			}
+
+			if perr.Column != c.column {
+				t.Errorf("Column: expected %v, got %v (%v)\n", c.column, perr.Column, err)
+			}
+		})
+	}
+}
+
+func TestParseEWKT_DeeplyNested(t *testing.T) {
+	nested := func(tag string, n int, inner string) string {
+		return strings.Repeat(tag+"(", n) + inner + strings.Repeat(")", n)
+	}
+
+	cases := []struct {
+		name  string
+		src   string
+		valid bool
+	}{
+		{"max depth", nested("GEOMETRYCOLLECTION", DefaultMaxDepth, "POINT(1 2)"), true},
+		{"too deep", nested("GEOMETRYCOLLECTION", DefaultMaxDepth+1, "POINT(1 2)"), false},
+		{"huge collection nesting", nested("GEOMETRYCOLLECTION", 3e6, "POINT(1 2)"), false},
+		{"huge curve nesting", nested("COMPOUNDCURVE", 3e6, "(1 2,3 4)"), false},
+		{"huge surface nesting", nested("MULTISURFACE", 3e6, "EMPTY"), false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := ParseEWKT(c.src)
+			if c.valid {
+				if err != nil {
+					t.Errorf("Expected: no errors, got error: %v\n", err)
+				}
+				return
+			}
+
+			var perr *ParseError
+			if !errors.As(err, &perr) {
+				t.Errorf("Expected ParseError, got %v\n", err)
+			}
+		})
+	}
+}
+
+func TestParseWKT(t *testing.T) {
+	g, err := ParseWKT("POINT(1 2)")
+	if err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	if typ := g.Type(); typ != PointType {
+		t.Errorf("Type: expected %v, got %v\n", PointType, typ)
+	}
+
+	if _, err := ParseWKT("SRID=4326;POINT(1 2)"); err == nil {
+		t.Error("Expected: error, got: no errors\n")
+	}
+}
+
+func TestParseEWKT_RoundTrip(t *testing.T) {
+	geoms := []Geometry{
+		func() Geometry {
+			p := NewPoint(NewBase(NDR, false, false, true, 4326), geo.NewPoint(-0.1, 51.5))
+			return &p
+		}(),
+		func() Geometry {
+			p := NewPolygon(
+				NewBase(NDR, true, true, true, 3857),
+				geo.NewPolygon([]geo.MultiPoint{
+					geo.NewMultiPoint([]geo.Point{
+						geo.NewPointZM(1.5, 2, 3, 4),
+						geo.NewPointZM(5, 6.25, 7, 8),
+						geo.NewPointZM(9, 10, 11.125, 12),
+						geo.NewPointZM(1.5, 2, 3, 4),
+					}),
+				}),
+			)
+			return &p
+		}(),
+		func() Geometry {
+			l := NewMultiLineString(
+				NewBase(NDR, false, true, false, 0),
+				geo.NewMultiLine([]geo.MultiPoint{
+					geo.NewMultiPoint([]geo.Point{
+						geo.NewPointM(1, 2, 3),
+						geo.NewPointM(4, 5, 6),
+					}),
+				}),
+			)
+			return &l
+		}(),
+		func() Geometry {
+			p := NewEmptyPoint(NewBase(NDR, true, false, false, 0))
+			return &p
+		}(),
+		func() Geometry {
+			p := NewEmptyMultiPoint(NewBase(NDR, true, true, true, 4326))
+			return &p
+		}(),
+		func() Geometry {
+			b := NewBase(NDR, true, false, false, 0)
+			p1 := NewEmptyPoint(b)
+			p2 := NewPoint(b, geo.NewPointZ(1, 2, 3))
+			c := NewGeometryCollection(b, []Geometry{&p1, &p2})
+			return &c
+		}(),
+		func() Geometry {
+			b := NewBase(NDR, true, true, false, 0)
+			l1 := NewEmptyLineString(b)
+			l2 := NewLineString(b, geo.NewMultiPoint([]geo.Point{
+				geo.NewPointZM(1, 2, 3, 4),
+				geo.NewPointZM(5, 6, 7, 8),
+			}))
+			c := NewCompoundCurve(b, []Geometry{&l1, &l2})
+			return &c
+		}(),
+		func() Geometry {
+			var c GeometryCollection
+			err := c.UnmarshalJSON([]byte(`{"type":"GeometryCollection","geometries":[` +
+				`{"type":"Point","coordinates":[]},{"type":"Point","coordinates":[1,2,3]}]}`))
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+			return &c
+		}(),
+	}
+
+	for _, g := range geoms {
+		t.Run(g.String(), func(t *testing.T) {
+			parsed, err := ParseEWKT(g.String())
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			if parsed.String() != g.String() {
+				t.Errorf("Expected %q, got %q\n", g.String(), parsed.String())
+			}
+
+			if err = parsed.Validate(); err != nil {
+				t.Errorf("Expected: no errors, got error: %v\n", err)
+			}
+
+			if parsed.HasZ() != g.HasZ() || parsed.HasM() != g.HasM() ||
+				parsed.HasSRID() != g.HasSRID() || parsed.SRID() != g.SRID() {
+				t.Errorf("Header: expected %v, got %v\n", g, parsed)
+			}
+		})
+	}
+}
+
+func TestWrapper_UnmarshalText(t *testing.T) {
+	var w Wrapper
+	if err := w.UnmarshalText([]byte("SRID=4326;LINESTRING(1 2,3 4)")); err != nil {
+		t.Fatalf("Expected: no errors, got error: %v\n", err)
+	}
+
+	if _, ok := w.Geometry.(*LineString); !ok {
+		t.Fatalf("Expected *LineString, got %T\n", w.Geometry)
+	}
+
+	if err := w.UnmarshalText([]byte("LINESTRING(1 2,3")); err == nil {
+		t.Fatal("Expected: error, got: no errors\n")
+	}
+
+	if w.Geometry != nil {
+		t.Errorf("Expected nil geometry, got %v\n", w.Geometry)
+	}
+}
+
+func TestWrapper_Options_WKT(t *testing.T) {
+	cases := []struct {
+		name  string
+		opts  DecodeOptions
+		valid bool
+	}{
+		{"no limits", DecodeOptions{}, true},
+		{"legacy", DecodeOptions{LegacyMulti: true}, true},
+		{"enough points", DecodeOptions{MaxPoints: 2}, true},
+		{"too many points", DecodeOptions{MaxPoints: 1}, false},
+		{"too many parts", DecodeOptions{MaxParts: 1}, false},
+		{"too deep", DecodeOptions{MaxDepth: 1}, false},
+	}
+
+	const wkt = "GEOMETRYCOLLECTION(GEOMETRYCOLLECTION(MULTIPOINT(1 2,3 4)))"
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			w := Wrapper{Options: c.opts}
+			errs := []error{w.Scan(wkt), w.UnmarshalText([]byte(wkt))}
+			for _, err := range errs {
+				if c.valid {
+					if err != nil {
+						t.Fatalf("Expected: no errors, got error: %v\n", err)
+					}
+					continue
+				}
+
+				if !errors.Is(err, ErrLimitExceeded) {
+					t.Errorf("Expected %v, got %v\n", ErrLimitExceeded, err)
+				}
+			}
+
+			if c.valid && w.Geometry.String() != wkt {
+				t.Errorf("Expected %q, got %q\n", wkt, w.Geometry.String())
+			}
+		})
+	}
+}
+
+func TestWrapper_Options_WKTLimits(t *testing.T) {
+	cases := []string{
+		"POINT(1 2)",
+		"POINT EMPTY",
+		"MULTIPOINT(1 2,EMPTY,3 4)",
+		"POLYGON((0 0,1 1,1 0,0 0),(0 0,1 1,1 0,0 0))",
+		"MULTIPOLYGON(((0 0,1 1,1 0,0 0)),((0 0,1 1,1 0,0 0)))",
+		"GEOMETRYCOLLECTION(MULTILINESTRING((1 2,3 4)),GEOMETRYCOLLECTION(POINT(1 2)))",
+		"CURVEPOLYGON(COMPOUNDCURVE(CIRCULARSTRING(0 0,1 1,2 0),(2 0,0 0)))",
+		"TIN(((0 0,1 1,1 0,0 0)),((0 0,1 1,1 0,0 0)))",
+	}
+
+	// Limits must be checked while parsing
+	// like they are checked while decoding
+	for _, wkt := range cases {
+		t.Run(wkt, func(t *testing.T) {
+			g, err := ParseEWKT(wkt)
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			data, err := g.MarshalBinary()
+			if err != nil {
+				t.Fatalf("Expected: no errors, got error: %v\n", err)
+			}
+
+			for limit := 1; limit < 12; limit++ {
+				for _, opts := range []DecodeOptions{
+					{MaxPoints: limit}, {MaxRings: limit}, {MaxParts: limit}, {MaxDepth: limit},
+				} {
+					_, berr := Unmarshal(data, opts)
+					w := Wrapper{Options: opts}
+					werr := w.Scan(wkt)
+					if (berr == nil) != (werr == nil) {
+						t.Errorf("Expected %v, got %v (%+v)\n", berr, werr, opts)
+					}
+					if werr != nil && !errors.Is(werr, ErrLimitExceeded) {
+						t.Errorf("Expected %v, got %v\n", ErrLimitExceeded, werr)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestWrapper_Scan_HostileWKT(t *testing.T) {
+	hostile := strings.Repeat("GEOMETRYCOLLECTION(", 3e5) + "POINT(1 2)" + strings.Repeat(")", 3e5)
+	cases := []struct {
+		name string
+		opts DecodeOptions
+	}{
+		{"input size", DecodeOptions{MaxDepth: 4, MaxInputSize: 1024}},
+		{"depth", DecodeOptions{MaxDepth: 4}},
+		{"default depth", DecodeOptions{}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			w := Wrapper{Options: c.opts}
+			err := w.Scan(hostile)
+			if !errors.Is(err, ErrLimitExceeded) {
+				t.Errorf("Expected %v, got %v\n", ErrLimitExceeded, err)
+			}
+
+			if w.Geometry != nil {
+				t.Errorf("Expected nil geometry, got %v\n", w.Geometry)
+			}
+		})
+	}
+}
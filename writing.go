@@ -3,10 +3,15 @@ package ewkb
 import (
 	"encoding/binary"
 	"math"
+	"slices"
 
 	"github.com/kcasctiv/go-ewkb/geo"
 )
 
+// emptyCoord is bit pattern of NaN, which PostGIS
+// writes for every coordinate of empty point
+const emptyCoord uint64 = 0x7FF8000000000000
+
 func headerSize(hasSRID bool) int {
 	if hasSRID {
 		return 9
@@ -32,6 +37,10 @@ func multiPointSize(mp geo.MultiPoint, hasZ, hasM bool) int {
 	return pointSize(hasZ, hasM)*mp.Len() + 4
 }
 
+func pointMembersSize(mp geo.MultiPoint, hasZ, hasM bool) int {
+	return (headerSize(false)+pointSize(hasZ, hasM))*mp.Len() + 4
+}
+
 func polygonSize(poly geo.Polygon, hasZ, hasM bool) int {
 	size := 4
 	for idx := 0; idx < poly.Len(); idx++ {
@@ -44,7 +53,7 @@ func polygonSize(poly geo.Polygon, hasZ, hasM bool) int {
 func multiPolygonSize(mp geo.MultiPolygon, hasZ, hasM bool) int {
 	size := 4
 	for idx := 0; idx < mp.Len(); idx++ {
-		size += polygonSize(mp.Polygon(idx), hasZ, hasM)
+		size += headerSize(false) + polygonSize(mp.Polygon(idx), hasZ, hasM)
 	}
 
 	return size
@@ -59,26 +68,70 @@ func multiLineSize(ml geo.MultiLine, hasZ, hasM bool) int {
 	return size
 }
 
-func collectionSize(geoms []Geometry, hasZ, hasM bool) int {
+// collectionSize returns size of members of collection,
+// every one of which is encoded with its own dimensions.
+// Members of types, not defined by package, are rejected
+func collectionSize(geoms []Geometry) (int, error) {
 	size := 4 + headerSize(false)*len(geoms)
 	for _, geom := range geoms {
+		if geom == nil {
+			continue
+		}
+
+		var n int
+		var err error
+		hasZ, hasM := geom.HasZ(), geom.HasM()
 		switch g := geom.(type) {
 		case *Point:
 			size += pointSize(hasZ, hasM)
 		case *LineString:
 			size += multiPointSize(g, hasZ, hasM)
 		case *MultiPoint:
-			size += multiPointSize(g, hasZ, hasM)
+			size += pointMembersSize(g, hasZ, hasM)
 		case *Polygon:
 			size += polygonSize(g, hasZ, hasM)
 		case *MultiLineString:
 			size += multiLineSize(g, hasZ, hasM)
 		case *MultiPolygon:
 			size += multiPolygonSize(g, hasZ, hasM)
+		case *GeometryCollection:
+			n, err = collectionSize(g.geoms)
+		case *CircularString:
+			size += multiPointSize(g, hasZ, hasM)
+		case *CompoundCurve:
+			n, err = collectionSize(g.curves)
+		case *CurvePolygon:
+			n, err = collectionSize(g.rings)
+		case *MultiCurve:
+			n, err = collectionSize(g.curves)
+		case *MultiSurface:
+			n, err = collectionSize(g.surfaces)
+		case *PolyhedralSurface:
+			size += multiPolygonSize(g, hasZ, hasM)
+		case *Tin:
+			size += multiPolygonSize(g.mp, hasZ, hasM)
+		case *Triangle:
+			size += polygonSize(g, hasZ, hasM)
+		default:
+			err = unsupportedType(geom)
+		}
+		if err != nil {
+			return 0, err
 		}
+		size += n
 	}
 
-	return size
+	return size, nil
+}
+
+// grow extends dst by n bytes, reallocating it if needed,
+// and returns extended slice and its last n bytes.
+// Reallocation is amortized, as it is done by append,
+// so appending to the same buffer in loop is linear
+func grow(dst []byte, n int) ([]byte, []byte) {
+	l := len(dst)
+	dst = slices.Grow(dst, n)[:l+n]
+	return dst, dst[l:]
 }
 
 func writeHeader(
@@ -86,9 +139,21 @@ func writeHeader(
 	typ uint32,
 	byteOrder binary.ByteOrder,
 	hasSRID bool,
+	opts EncodeOptions,
 	b []byte,
 ) int {
-	b[0] = base.ByteOrder()
+	// Members are encoded with byte order of geometry,
+	// so byte order is taken from encoding, not from base
+	b[0] = NDR
+	if byteOrder == binary.BigEndian {
+		b[0] = XDR
+	}
+
+	if opts.ISO {
+		byteOrder.PutUint32(b[1:], typ+isoTypeOffset(base.HasZ(), base.HasM()))
+		return 5
+	}
+
 	byteOrder.PutUint32(b[1:], typ|getFlags(base.HasZ(), base.HasM(), hasSRID))
 
 	if !hasSRID {
@@ -100,12 +165,35 @@ func writeHeader(
 	return 9
 }
 
+// isoTypeOffset returns offset of ISO WKB type code for dimensions
+func isoTypeOffset(hasZ, hasM bool) uint32 {
+	var offset uint32
+	if hasZ {
+		offset += isoZOffset
+	}
+
+	if hasM {
+		offset += isoMOffset
+	}
+
+	return offset
+}
+
 func writePoint(
 	p geo.Point,
 	byteOrder binary.ByteOrder,
 	hasZ, hasM bool,
 	b []byte,
 ) int {
+	if isEmptyPoint(p, hasZ, hasM) {
+		size := pointSize(hasZ, hasM)
+		for offset := 0; offset < size; offset += 8 {
+			byteOrder.PutUint64(b[offset:], emptyCoord)
+		}
+
+		return size
+	}
+
 	byteOrder.PutUint64(b, math.Float64bits(p.X()))
 	offset := 8
 
@@ -134,6 +222,15 @@ func writeMultiPoint(
 	byteOrder.PutUint32(b, uint32(mp.Len()))
 	offset := 4
 
+	if s, ok := sequenceOf(mp); ok && s.HasZ() == hasZ && s.HasM() == hasM {
+		for _, v := range s.Flat() {
+			byteOrder.PutUint64(b[offset:], math.Float64bits(v))
+			offset += 8
+		}
+
+		return offset
+	}
+
 	for idx := 0; idx < mp.Len(); idx++ {
 		offset += writePoint(mp.Point(idx), byteOrder, hasZ, hasM, b[offset:])
 	}
@@ -141,6 +238,41 @@ func writeMultiPoint(
 	return offset
 }
 
+// sequenceOf returns flat sequence, underlying mp, if any
+func sequenceOf(mp geo.MultiPoint) (geo.Sequence, bool) {
+	switch s := mp.(type) {
+	case geo.Sequence:
+		return s, true
+	case *geo.Sequence:
+		return *s, true
+	case *LineString:
+		return sequenceOf(s.mp)
+	case *CircularString:
+		return sequenceOf(s.mp)
+	default:
+		return geo.Sequence{}, false
+	}
+}
+
+// writePointMembers writes points of MultiPoint,
+// preceding every one of them by its own header
+func writePointMembers(
+	p *MultiPoint,
+	byteOrder binary.ByteOrder,
+	opts EncodeOptions,
+	b []byte,
+) int {
+	byteOrder.PutUint32(b, uint32(p.Len()))
+	offset := 4
+
+	for idx := 0; idx < p.Len(); idx++ {
+		offset += writeHeader(p, PointType, byteOrder, false, opts, b[offset:])
+		offset += writePoint(p.Point(idx), byteOrder, p.HasZ(), p.HasM(), b[offset:])
+	}
+
+	return offset
+}
+
 func writePolygon(
 	p geo.Polygon,
 	byteOrder binary.ByteOrder,
@@ -158,16 +290,19 @@ func writePolygon(
 }
 
 func writeMultiPolygon(
-	p geo.MultiPolygon,
+	base Base,
+	mp geo.MultiPolygon,
+	typ uint32,
 	byteOrder binary.ByteOrder,
-	hasZ, hasM bool,
+	opts EncodeOptions,
 	b []byte,
 ) int {
-	byteOrder.PutUint32(b, uint32(p.Len()))
+	byteOrder.PutUint32(b, uint32(mp.Len()))
 	offset := 4
 
-	for idx := 0; idx < p.Len(); idx++ {
-		offset += writePolygon(p.Polygon(idx), byteOrder, hasZ, hasM, b[offset:])
+	for idx := 0; idx < mp.Len(); idx++ {
+		offset += writeHeader(base, typ, byteOrder, false, opts, b[offset:])
+		offset += writePolygon(mp.Polygon(idx), byteOrder, base.HasZ(), base.HasM(), b[offset:])
 	}
 
 	return offset
@@ -177,45 +312,77 @@ func writeMultiLine(
 	l *MultiLineString,
 	byteOrder binary.ByteOrder,
 	hasZ, hasM bool,
+	opts EncodeOptions,
 	b []byte,
 ) int {
 	byteOrder.PutUint32(b, uint32(l.Len()))
 	offset := 4
 
 	for idx := 0; idx < l.Len(); idx++ {
-		offset += writeHeader(l, LineType, byteOrder, false, b[offset:])
+		offset += writeHeader(l, LineType, byteOrder, false, opts, b[offset:])
 		offset += writeMultiPoint(l.Line(idx), byteOrder, hasZ, hasM, b[offset:])
 	}
 
 	return offset
 }
 
+// writeCollection writes members of collection, coordinates
+// of every one of which are written with dimensions of its
+// own header, so encoded members are always well-formed.
+// Members of types, not defined by package, are rejected
 func writeCollection(
 	geoms []Geometry,
 	byteOrder binary.ByteOrder,
-	hasZ, hasM bool,
+	opts EncodeOptions,
 	b []byte,
-) int {
+) (int, error) {
 	byteOrder.PutUint32(b, uint32(len(geoms)))
 	offset := 4
 
 	for _, geom := range geoms {
-		offset += writeHeader(geom, geom.Type(), byteOrder, false, b[offset:])
+		var n int
+		var err error
+		hasZ, hasM := geom.HasZ(), geom.HasM()
+		offset += writeHeader(geom, geom.Type(), byteOrder, false, opts, b[offset:])
 		switch g := geom.(type) {
 		case *Point:
 			offset += writePoint(g, byteOrder, hasZ, hasM, b[offset:])
 		case *LineString:
 			offset += writeMultiPoint(g, byteOrder, hasZ, hasM, b[offset:])
 		case *MultiPoint:
-			offset += writeMultiPoint(g, byteOrder, hasZ, hasM, b[offset:])
+			offset += writePointMembers(g, byteOrder, opts, b[offset:])
 		case *Polygon:
 			offset += writePolygon(g, byteOrder, hasZ, hasM, b[offset:])
 		case *MultiLineString:
-			offset += writeMultiLine(g, byteOrder, hasZ, hasM, b[offset:])
+			offset += writeMultiLine(g, byteOrder, hasZ, hasM, opts, b[offset:])
 		case *MultiPolygon:
-			offset += writeMultiPolygon(g, byteOrder, hasZ, hasM, b[offset:])
+			offset += writeMultiPolygon(g, g, PolygonType, byteOrder, opts, b[offset:])
+		case *GeometryCollection:
+			n, err = writeCollection(g.geoms, byteOrder, opts, b[offset:])
+		case *CircularString:
+			offset += writeMultiPoint(g, byteOrder, hasZ, hasM, b[offset:])
+		case *CompoundCurve:
+			n, err = writeCollection(g.curves, byteOrder, opts, b[offset:])
+		case *CurvePolygon:
+			n, err = writeCollection(g.rings, byteOrder, opts, b[offset:])
+		case *MultiCurve:
+			n, err = writeCollection(g.curves, byteOrder, opts, b[offset:])
+		case *MultiSurface:
+			n, err = writeCollection(g.surfaces, byteOrder, opts, b[offset:])
+		case *PolyhedralSurface:
+			offset += writeMultiPolygon(g, g, PolygonType, byteOrder, opts, b[offset:])
+		case *Tin:
+			offset += writeMultiPolygon(g, g.mp, TriangleType, byteOrder, opts, b[offset:])
+		case *Triangle:
+			offset += writePolygon(g, byteOrder, hasZ, hasM, b[offset:])
+		default:
+			err = unsupportedType(geom)
 		}
+		if err != nil {
+			return 0, err
+		}
+		offset += n
 	}
 
-	return offset
+	return offset, nil
 }